@@ -48,6 +48,12 @@ type AuthOptions struct {
 	// Passcode is used in TOTP authentication method
 	Passcode string `json:"passcode,omitempty"`
 
+	// AuthReceipt is the ID of the auth receipt issued by Keystone when a
+	// previous attempt only satisfied some of the multi-factor authentication
+	// rules of the user. When set, the request only needs to provide the
+	// remaining methods, e.g. a TOTP Passcode. See tokens.ErrAuthReceiptRequired.
+	AuthReceipt string `json:"-"`
+
 	// At most one of DomainID and DomainName must be provided if using Username
 	// with Identity V3. Otherwise, either are optional.
 	DomainID   string `json:"-"`
@@ -93,6 +99,91 @@ type AuthOptions struct {
 	ApplicationCredentialID     string `json:"-"`
 	ApplicationCredentialName   string `json:"-"`
 	ApplicationCredentialSecret string `json:"-"`
+
+	// OIDC enables authentication through an OpenID Connect identity provider
+	// federated with Keystone. It is only supported by the Identity V3 API.
+	// The Username and Password are used with the OIDCGrantPassword grant
+	// type, and the TenantID, TenantName and Scope select the scope of the
+	// token.
+	OIDC *OIDCAuthOptions `json:"-"`
+
+	// TokenCache, when set, lets openstack.Authenticate reuse the tokens
+	// cached by previous authentications with the same options, possibly by
+	// other processes, instead of requesting a new one. It is only supported
+	// by the Identity V3 API, and is not used with a Passcode.
+	TokenCache TokenCache `json:"-"`
+}
+
+// OIDCGrantType is the OAuth 2.0 grant used to obtain an access token from an
+// OpenID Connect identity provider.
+type OIDCGrantType string
+
+const (
+	// OIDCGrantPassword obtains an access token with the username and
+	// password of the user.
+	OIDCGrantPassword OIDCGrantType = "password"
+
+	// OIDCGrantClientCredentials obtains an access token with the client ID
+	// and client secret only.
+	OIDCGrantClientCredentials OIDCGrantType = "client_credentials"
+
+	// OIDCGrantAuthorizationCode obtains an access token with an
+	// authorization code issued to the user by the identity provider. The
+	// code can only be used once, so re-authentication is not possible.
+	OIDCGrantAuthorizationCode OIDCGrantType = "authorization_code"
+)
+
+// OIDCAuthOptions stores the information needed to authenticate through an
+// OpenID Connect identity provider federated with Keystone.
+//
+// An access token is obtained from the identity provider with GrantType, or
+// AccessToken is used when set. It is exchanged for an unscoped Keystone token
+// at the OS-FEDERATION endpoint of IdentityProvider and Protocol, which is
+// then rescoped.
+type OIDCAuthOptions struct {
+	// IdentityProvider is the ID of the identity provider in Keystone.
+	IdentityProvider string
+
+	// Protocol is the ID of the federation protocol in Keystone, usually
+	// "openid".
+	Protocol string
+
+	// GrantType is the grant used to obtain an access token. It is ignored
+	// when AccessToken is set.
+	GrantType OIDCGrantType
+
+	// AccessToken is an access token previously obtained from the identity
+	// provider.
+	AccessToken string
+
+	// ClientID and ClientSecret identify the client to the identity provider.
+	// When ClientSecret is empty, the client is authenticated as a public
+	// client.
+	ClientID     string
+	ClientSecret string
+
+	// DiscoveryEndpoint is the URL of the OpenID Connect discovery document
+	// of the identity provider, from which the token endpoint is read. It is
+	// ignored when AccessTokenEndpoint is set.
+	DiscoveryEndpoint string
+
+	// AccessTokenEndpoint is the URL of the token endpoint of the identity
+	// provider.
+	AccessTokenEndpoint string
+
+	// AccessTokenType is the field of the token endpoint response holding the
+	// token passed to Keystone. Defaults to "access_token"; some deployments
+	// expect "id_token".
+	AccessTokenType string
+
+	// Scopes are the OpenID Connect scopes requested from the identity
+	// provider. Defaults to "openid" and "profile".
+	Scopes []string
+
+	// AuthorizationCode and RedirectURI are used with the
+	// OIDCGrantAuthorizationCode grant type.
+	AuthorizationCode string
+	RedirectURI       string
 }
 
 // AuthScope allows a created token to be limited to a specific domain or project.
@@ -502,8 +593,8 @@ func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]any, error) {
 }
 
 func (opts AuthOptions) CanReauth() bool {
-	if opts.Passcode != "" {
-		// cannot reauth using TOTP passcode
+	if opts.Passcode != "" || opts.AuthReceipt != "" {
+		// cannot reauth using TOTP passcode or an auth receipt
 		return false
 	}
 
@@ -511,7 +602,11 @@ func (opts AuthOptions) CanReauth() bool {
 }
 
 // ToTokenV3HeadersMap allows AuthOptions to satisfy the AuthOptionsBuilder
-// interface in the v3 tokens package.
+// interface in the v3 tokens package. It returns the auth receipt header, if
+// any.
 func (opts *AuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
-	return nil, nil
+	if opts.AuthReceipt == "" {
+		return nil, nil
+	}
+	return map[string]string{"Openstack-Auth-Receipt": opts.AuthReceipt}, nil
 }
@@ -1,5 +1,7 @@
 package gophercloud
 
+import "time"
+
 /*
 AuthResult is the result from the request that was used to obtain a provider
 client's Keystone token. It is returned from ProviderClient.GetAuthResult().
@@ -50,3 +52,12 @@ type tokens3.User).
 type AuthResult interface {
 	ExtractTokenID() (string, error)
 }
+
+// ExpiringAuthResult is an AuthResult that reports when its token expires. The
+// CreateResult and GetResult types of the v2 and v3 tokens packages satisfy
+// it. It allows ProviderClient to renew the token ahead of its expiry, see
+// ProviderClient.TokenRenewalMargin.
+type ExpiringAuthResult interface {
+	AuthResult
+	ExtractExpiresAt() (time.Time, error)
+}
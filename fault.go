@@ -0,0 +1,216 @@
+package gophercloud
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// requestIDHeaders are the response headers that carry the ID assigned to
+// a request by an OpenStack service, in order of preference.
+var requestIDHeaders = []string{
+	"X-Openstack-Request-Id",
+	"X-Compute-Request-Id",
+	"X-Request-Id",
+}
+
+// Fault is the normalized representation of the error document returned by an
+// OpenStack service along with an unexpected response code.
+//
+// Each service wraps its errors differently: Nova, Cinder and Manila use
+// {"itemNotFound": {"message": ..., "code": ...}}, Neutron uses
+// {"NeutronError": {"type": ..., "message": ..., "detail": ...}}, Keystone
+// uses {"error": {"code": ..., "title": ..., "message": ...}}, Octavia and
+// Ironic use "faultstring", Placement uses the API-WG {"errors": [...]}
+// format. Fault extracts the same information from all of them.
+type Fault struct {
+	// Type identifies the kind of error, e.g. "itemNotFound" for Nova,
+	// "PortNotFound" for Neutron, or "Not Found" for Keystone. It is empty
+	// when the service does not report one.
+	Type string
+
+	// Code is the error code reported in the error document. It is usually
+	// the HTTP status code, and 0 when the service does not report one.
+	Code int
+
+	// Message is the human-readable error message. When the response body is
+	// not a known error document, it holds the body text.
+	Message string
+
+	// Details holds additional information about the error, if any.
+	Details string
+
+	// RequestID is the ID assigned to the request by the service, from the
+	// X-Openstack-Request-Id or X-Compute-Request-Id response header.
+	RequestID string
+}
+
+// Fault parses the response body of the error into a Fault.
+func (e ErrUnexpectedResponseCode) Fault() Fault {
+	f := parseFault(e.Body)
+	f.RequestID = e.RequestID()
+	return f
+}
+
+// ExtractFault returns the Fault of the ErrUnexpectedResponseCode contained in
+// err, if any. For example, this checks if a port could not be found:
+//
+//	_, err := ports.Get(context.TODO(), client, id).Extract()
+//	if fault, ok := gophercloud.ExtractFault(err); ok && fault.Type == "PortNotFound" {
+//		handleNotFound()
+//	}
+//
+// It is safe to pass a nil error, in which case this function always returns false.
+func ExtractFault(err error) (Fault, bool) {
+	var codeError ErrUnexpectedResponseCode
+	if errors.As(err, &codeError) {
+		return codeError.Fault(), true
+	}
+	return Fault{}, false
+}
+
+// faultBody is the union of the fields of the error documents returned by
+// the OpenStack services.
+type faultBody struct {
+	Type        string          `json:"type"`
+	Title       string          `json:"title"`
+	Code        json.RawMessage `json:"code"`
+	Status      int             `json:"status"`
+	Message     string          `json:"message"`
+	Description string          `json:"description"`
+	Detail      string          `json:"detail"`
+	Details     json.RawMessage `json:"details"`
+
+	// Octavia and Ironic
+	FaultCode   string `json:"faultcode"`
+	FaultString string `json:"faultstring"`
+	DebugInfo   string `json:"debuginfo"`
+
+	// Ironic wraps a JSON encoded fault in a string.
+	ErrorMessage string `json:"error_message"`
+
+	// Heat and Keystone nest the error.
+	Error json.RawMessage `json:"error"`
+
+	// Placement and the API-WG guidelines.
+	Errors []faultBody `json:"errors"`
+}
+
+func parseFault(body []byte) Fault {
+	text := strings.TrimSpace(string(body))
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return Fault{Message: text}
+	}
+
+	// Nova, Cinder, Manila and Neutron wrap the error in an object with a
+	// single key, which is the error type for the former.
+	if len(doc) == 1 {
+		for k, v := range doc {
+			var inner faultBody
+			if k == "error" || json.Unmarshal(v, &inner) != nil || (inner.Message == "" && inner.Type == "") {
+				break
+			}
+			f := inner.fault()
+			if f.Type == "" && k != "NeutronError" {
+				f.Type = k
+			}
+			return f
+		}
+	}
+
+	var fb faultBody
+	if err := json.Unmarshal(body, &fb); err != nil {
+		return Fault{Message: text}
+	}
+	f := fb.fault()
+	if f == (Fault{}) {
+		f.Message = text
+	}
+	return f
+}
+
+func (fb faultBody) fault() Fault {
+	if len(fb.Errors) > 0 {
+		return fb.Errors[0].fault()
+	}
+
+	if fb.ErrorMessage != "" {
+		var nested faultBody
+		if err := json.Unmarshal([]byte(fb.ErrorMessage), &nested); err == nil {
+			return nested.fault()
+		}
+		return Fault{Message: fb.ErrorMessage}
+	}
+
+	f := Fault{
+		Type:    firstNonEmpty(fb.Type, fb.Title, fb.FaultCode),
+		Code:    parseFaultCode(fb.Code, fb.Status),
+		Message: firstNonEmpty(fb.Message, fb.FaultString, fb.Description),
+		Details: firstNonEmpty(fb.Detail, rawString(fb.Details), fb.DebugInfo),
+	}
+
+	if len(fb.Error) > 0 {
+		var nested faultBody
+		if err := json.Unmarshal(fb.Error, &nested); err == nil {
+			inner := nested.fault()
+			f.Type = firstNonEmpty(inner.Type, f.Type)
+			f.Code = max(inner.Code, f.Code)
+			f.Message = firstNonEmpty(inner.Message, f.Message)
+			f.Details = firstNonEmpty(inner.Details, f.Details)
+		}
+	}
+
+	return f
+}
+
+// parseFaultCode returns the numeric error code of a fault, which some
+// services encode as a string. The code of the API-WG format is a string
+// identifier, in which case the status is used.
+func parseFaultCode(code json.RawMessage, status int) int {
+	var n int
+	if err := json.Unmarshal(code, &n); err == nil {
+		return n
+	}
+	var s string
+	if err := json.Unmarshal(code, &s); err == nil {
+		if n, err := strconv.Atoi(s); err == nil {
+			return n
+		}
+	}
+	return status
+}
+
+// rawString returns a JSON value as a string: strings are unquoted, other
+// values are returned as JSON, and null is returned as "".
+func rawString(v json.RawMessage) string {
+	if len(v) == 0 || string(v) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return s
+	}
+	return string(v)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func requestIDFromHeader(h http.Header) string {
+	for _, k := range requestIDHeaders {
+		if v := h.Get(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
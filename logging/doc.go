@@ -0,0 +1,35 @@
+/*
+Package logging provides a log/slog based debug logger for the HTTP traffic
+of a ProviderClient.
+
+Each request and response is logged with its method, URL, OpenStack service
+type, headers and body. JSON bodies are pretty-printed, long bodies are
+truncated, and binary bodies such as image data or object contents are never
+read: only their size and content type are logged.
+
+Credentials are redacted before anything is logged. This covers the
+authentication headers (X-Auth-Token, X-Subject-Token...), the secret fields
+found in JSON bodies (passwords, application credential secrets, TOTP
+passcodes, Barbican payloads...) and the bodies of Barbican secret payload
+requests. Additional headers and fields can be redacted with
+WithRedactedHeaders and WithRedactedFields.
+
+Example to Log the HTTP Traffic of a ProviderClient
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+	}))
+
+	providerClient, err := openstack.NewClient(authOptions.IdentityEndpoint)
+	if err != nil {
+		panic(err)
+	}
+
+	logging.Attach(providerClient, logger)
+
+	err = openstack.Authenticate(context.TODO(), providerClient, authOptions)
+	if err != nil {
+		panic(err)
+	}
+*/
+package logging
@@ -0,0 +1,303 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+const (
+	// DefaultMaxBodyLength is the number of bytes of a body that are logged
+	// before it is truncated.
+	DefaultMaxBodyLength = 4096
+
+	// maxJSONLength is the size above which JSON bodies are not parsed, and
+	// hence not logged, since they could not be redacted.
+	maxJSONLength = 1 << 20
+)
+
+// Option configures the logger.
+type Option func(*config)
+
+type config struct {
+	level         slog.Level
+	maxBodyLength int
+	headers       []string
+	fields        []string
+}
+
+// WithLevel sets the level at which requests and responses are logged.
+// Defaults to slog.LevelDebug.
+func WithLevel(level slog.Level) Option {
+	return func(c *config) {
+		c.level = level
+	}
+}
+
+// WithMaxBodyLength sets the number of bytes of a body that are logged before
+// it is truncated. Defaults to DefaultMaxBodyLength. A negative value disables
+// body logging.
+func WithMaxBodyLength(n int) Option {
+	return func(c *config) {
+		c.maxBodyLength = n
+	}
+}
+
+// WithRedactedHeaders adds HTTP headers to DefaultRedactedHeaders.
+func WithRedactedHeaders(headers ...string) Option {
+	return func(c *config) {
+		c.headers = append(c.headers, headers...)
+	}
+}
+
+// WithRedactedFields adds JSON object keys to DefaultRedactedFields.
+func WithRedactedFields(fields ...string) Option {
+	return func(c *config) {
+		c.fields = append(c.fields, fields...)
+	}
+}
+
+// Attach adds the Middleware returned by NewMiddleware to client.
+func Attach(client *gophercloud.ProviderClient, logger *slog.Logger, opts ...Option) {
+	client.Use(NewMiddleware(logger, opts...))
+}
+
+// NewMiddleware returns a gophercloud.Middleware that logs every HTTP request
+// and response to logger.
+func NewMiddleware(logger *slog.Logger, opts ...Option) gophercloud.Middleware {
+	c := config{
+		level:         slog.LevelDebug,
+		maxBodyLength: DefaultMaxBodyLength,
+		headers:       append([]string(nil), DefaultRedactedHeaders...),
+		fields:        append([]string(nil), DefaultRedactedFields...),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	l := &debugLogger{
+		logger:        logger,
+		level:         c.level,
+		maxBodyLength: c.maxBodyLength,
+		redactor:      newRedactor(c.headers, c.fields),
+	}
+	return l.middleware
+}
+
+type debugLogger struct {
+	logger        *slog.Logger
+	level         slog.Level
+	maxBodyLength int
+	redactor      redactor
+}
+
+func (l *debugLogger) middleware(next gophercloud.RequestFunc) gophercloud.RequestFunc {
+	return func(req *http.Request, info *gophercloud.RequestInfo) (*http.Response, error) {
+		ctx := req.Context()
+		if !l.logger.Enabled(ctx, l.level) {
+			return next(req, info)
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+		}
+		if info.ServiceType != "" {
+			attrs = append(attrs, slog.String("service_type", info.ServiceType))
+		}
+		if info.Microversion != "" {
+			attrs = append(attrs, slog.String("microversion", info.Microversion))
+		}
+		if info.Retries > 0 {
+			attrs = append(attrs, slog.Uint64("retries", uint64(info.Retries)))
+		}
+		if info.Reauthenticated {
+			attrs = append(attrs, slog.Bool("reauthenticated", true))
+		}
+
+		reqAttrs := append(slices.Clip(attrs), l.headerAttr(req.Header))
+		if body, ok := l.requestBody(req); ok {
+			reqAttrs = append(reqAttrs, slog.String("body", body))
+		}
+		l.logger.LogAttrs(ctx, l.level, "OpenStack request", reqAttrs...)
+
+		start := time.Now()
+		resp, err := next(req, info)
+		attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			l.logger.LogAttrs(ctx, l.level, "OpenStack request failed", attrs...)
+			return resp, err
+		}
+
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			l.headerAttr(resp.Header),
+		)
+		if body, ok := l.responseBody(req, resp); ok {
+			attrs = append(attrs, slog.String("body", body))
+		}
+		l.logger.LogAttrs(ctx, l.level, "OpenStack response", attrs...)
+
+		return resp, nil
+	}
+}
+
+// headerAttr returns the redacted headers as a group, in a stable order.
+func (l *debugLogger) headerAttr(h http.Header) slog.Attr {
+	redacted := l.redactor.header(h)
+	keys := make([]string, 0, len(redacted))
+	for k := range redacted {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]any, 0, len(keys))
+	for _, k := range keys {
+		attrs = append(attrs, slog.String(k, strings.Join(redacted[k], ", ")))
+	}
+	return slog.Group("headers", attrs...)
+}
+
+// requestBody returns the loggable representation of the request body. The
+// body is only read when it can be obtained again through GetBody, so that
+// streamed uploads are never consumed.
+func (l *debugLogger) requestBody(req *http.Request) (string, bool) {
+	if l.maxBodyLength < 0 || req.Body == nil || req.Body == http.NoBody {
+		return "", false
+	}
+	if isSecretPayload(req) {
+		return Redacted, true
+	}
+
+	contentType := req.Header.Get("Content-Type")
+	if !isJSON(contentType) || req.GetBody == nil {
+		return omitted(req.ContentLength, contentType), true
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Sprintf("<unable to read body: %v>", err), true
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxJSONLength+1))
+	if err != nil {
+		return fmt.Sprintf("<unable to read body: %v>", err), true
+	}
+	return l.formatJSON(data), true
+}
+
+// responseBody returns the loggable representation of the response body.
+// Only JSON and text bodies are read, and the bytes that were read are put
+// back in front of the remaining body so that the caller still sees all of
+// it.
+func (l *debugLogger) responseBody(req *http.Request, resp *http.Response) (string, bool) {
+	if l.maxBodyLength < 0 || resp.Body == nil || resp.Body == http.NoBody || req.Method == http.MethodHead {
+		return "", false
+	}
+	if isSecretPayload(req) {
+		return Redacted, true
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	limit := int64(l.maxBodyLength)
+	switch {
+	case isJSON(contentType):
+		limit = maxJSONLength
+	case isText(contentType):
+	default:
+		return omitted(resp.ContentLength, contentType), true
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	resp.Body = &readCloser{
+		Reader: io.MultiReader(bytes.NewReader(data), resp.Body),
+		Closer: resp.Body,
+	}
+	if err != nil {
+		return fmt.Sprintf("<unable to read body: %v>", err), true
+	}
+	if len(data) == 0 {
+		return "", false
+	}
+
+	if isJSON(contentType) {
+		return l.formatJSON(data), true
+	}
+	return l.truncate(string(data)), true
+}
+
+// formatJSON redacts and pretty-prints a JSON document. Documents that are
+// too large or invalid are omitted rather than logged unredacted.
+func (l *debugLogger) formatJSON(data []byte) string {
+	if len(data) > maxJSONLength {
+		return fmt.Sprintf("<JSON body larger than %d bytes omitted>", maxJSONLength)
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Sprintf("<invalid JSON body of %d bytes omitted>", len(data))
+	}
+
+	pretty, err := json.MarshalIndent(l.redactor.json(v, ""), "", "  ")
+	if err != nil {
+		return fmt.Sprintf("<unable to format body: %v>", err)
+	}
+	return l.truncate(string(pretty))
+}
+
+func (l *debugLogger) truncate(s string) string {
+	if len(s) <= l.maxBodyLength {
+		return s
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", s[:l.maxBodyLength], len(s)-l.maxBodyLength)
+}
+
+func omitted(length int64, contentType string) string {
+	if contentType == "" {
+		contentType = "unknown content type"
+	}
+	if length < 0 {
+		return fmt.Sprintf("<body of %s omitted>", contentType)
+	}
+	return fmt.Sprintf("<%d bytes of %s omitted>", length, contentType)
+}
+
+// isSecretPayload reports whether req targets a Barbican secret payload, the
+// body of which is the secret itself.
+func isSecretPayload(req *http.Request) bool {
+	return strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), "/payload")
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func isText(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/")
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,109 @@
+package logging
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Redacted replaces the value of every redacted header and JSON field.
+const Redacted = "***"
+
+// DefaultRedactedHeaders lists the HTTP headers whose value is never logged.
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Openstack-Auth-Receipt",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Account-Meta-Temp-Url-Key",
+	"X-Account-Meta-Temp-Url-Key-2",
+	"X-Auth-Key",
+	"X-Auth-Token",
+	"X-Container-Meta-Temp-Url-Key",
+	"X-Container-Meta-Temp-Url-Key-2",
+	"X-Service-Token",
+	"X-Storage-Token",
+	"X-Subject-Token",
+}
+
+// DefaultRedactedFields lists the JSON object keys whose value is never
+// logged, wherever they appear in a request or response body.
+var DefaultRedactedFields = []string{
+	"access_token",
+	"adminPass",
+	"admin_pass",
+	"application_credential_secret",
+	"blob",
+	"client_secret",
+	"id_token",
+	"passcode",
+	"password",
+	"payload",
+	"private_key",
+	"refresh_token",
+	"secret",
+}
+
+// redactor removes credentials from headers and JSON documents.
+type redactor struct {
+	headers map[string]bool
+	fields  map[string]bool
+}
+
+func newRedactor(headers, fields []string) redactor {
+	r := redactor{
+		headers: make(map[string]bool, len(headers)),
+		fields:  make(map[string]bool, len(fields)),
+	}
+	for _, h := range headers {
+		r.headers[http.CanonicalHeaderKey(h)] = true
+	}
+	for _, f := range fields {
+		r.fields[strings.ToLower(f)] = true
+	}
+	return r
+}
+
+// header returns a copy of h with the redacted headers masked.
+func (r redactor) header(h http.Header) http.Header {
+	redacted := make(http.Header, len(h))
+	for k, v := range h {
+		if r.headers[http.CanonicalHeaderKey(k)] {
+			redacted[k] = []string{Redacted}
+			continue
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// json masks the redacted fields of a decoded JSON document in place. Fields
+// holding an object or an array are walked rather than masked, so that e.g.
+// the user name of the Keystone "password" authentication method is kept. The
+// token ID passed to Keystone in the "token" authentication method is masked
+// as well.
+func (r redactor) json(v any, parent string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			_, isObject := child.(map[string]any)
+			_, isArray := child.([]any)
+			switch {
+			case r.fields[strings.ToLower(k)] && !isObject && !isArray:
+				v[k] = Redacted
+			case parent == "identity" && k == "token":
+				v[k] = Redacted
+			default:
+				v[k] = r.json(child, k)
+			}
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = r.json(child, parent)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,2 @@
+// logging unit tests
+package testing
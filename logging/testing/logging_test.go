@@ -0,0 +1,196 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/logging"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+type record map[string]any
+
+func setup(t *testing.T, opts ...logging.Option) (*gophercloud.ServiceClient, func() []record) {
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	p := &gophercloud.ProviderClient{TokenID: "secret-token"}
+	logging.Attach(p, logger, opts...)
+
+	sc := &gophercloud.ServiceClient{
+		ProviderClient: p,
+		Endpoint:       th.Endpoint(),
+		Type:           "identity",
+	}
+
+	records := func() []record {
+		var rs []record
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var r record
+			th.AssertNoErr(t, json.Unmarshal([]byte(line), &r))
+			rs = append(rs, r)
+		}
+		return rs
+	}
+	return sc, records
+}
+
+func TestLogRedactsCredentials(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", "new-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token": {"methods": ["password"]}, "application_credential": {"secret": "s3cr3t"}}`)
+	})
+
+	sc, records := setup(t)
+
+	body := map[string]any{
+		"auth": map[string]any{
+			"identity": map[string]any{
+				"methods":  []string{"password", "token"},
+				"password": map[string]any{"user": map[string]any{"name": "admin", "password": "hunter2"}},
+				"token":    map[string]any{"id": "old-token"},
+			},
+		},
+	}
+	var actual map[string]any
+	_, err := sc.Post(context.TODO(), sc.ServiceURL("auth", "tokens"), body, &actual, nil)
+	th.AssertNoErr(t, err)
+
+	// The caller still gets the full, unredacted response.
+	th.CheckEquals(t, "s3cr3t", actual["application_credential"].(map[string]any)["secret"])
+
+	rs := records()
+	th.AssertEquals(t, 2, len(rs))
+
+	req := rs[0]
+	th.CheckEquals(t, "OpenStack request", req["msg"])
+	th.CheckEquals(t, "POST", req["method"])
+	th.CheckEquals(t, "identity", req["service_type"])
+	th.CheckEquals(t, logging.Redacted, req["headers"].(map[string]any)["X-Auth-Token"])
+	reqBody := req["body"].(string)
+	for _, secret := range []string{"hunter2", "old-token", "secret-token"} {
+		if strings.Contains(reqBody, secret) {
+			t.Errorf("Request body leaks %q: %s", secret, reqBody)
+		}
+	}
+	if !strings.Contains(reqBody, `"name": "admin"`) {
+		t.Errorf("Request body is not pretty-printed: %s", reqBody)
+	}
+
+	resp := rs[1]
+	th.CheckEquals(t, "OpenStack response", resp["msg"])
+	th.CheckEquals(t, float64(201), resp["status"])
+	th.CheckEquals(t, logging.Redacted, resp["headers"].(map[string]any)["X-Subject-Token"])
+	if strings.Contains(resp["body"].(string), "s3cr3t") {
+		t.Errorf("Response body leaks the secret: %s", resp["body"])
+	}
+}
+
+func TestLogOmitsBinaryBodies(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	data := strings.Repeat("x", 10000)
+	th.Mux.HandleFunc("/objects/blob", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		fmt.Fprint(w, data)
+	})
+
+	sc, records := setup(t)
+
+	resp, err := sc.Get(context.TODO(), sc.ServiceURL("objects", "blob"), nil, &gophercloud.RequestOpts{
+		KeepResponseBody: true,
+	})
+	th.AssertNoErr(t, err)
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, data, string(b))
+
+	rs := records()
+	th.CheckEquals(t, "<10000 bytes of application/octet-stream omitted>", rs[1]["body"])
+}
+
+func TestLogTruncatesAndPreservesBody(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = fmt.Sprintf("server-%03d", i)
+	}
+	th.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"names": names})
+	})
+
+	sc, records := setup(t, logging.WithMaxBodyLength(64))
+
+	var actual struct {
+		Names []string `json:"names"`
+	}
+	_, err := sc.Get(context.TODO(), sc.ServiceURL("servers"), &actual, nil)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, names, actual.Names)
+
+	body := records()[1]["body"].(string)
+	if !strings.Contains(body, "bytes truncated)") {
+		t.Errorf("Expected a truncated body, got %s", body)
+	}
+}
+
+func TestLogSecretPayload(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/secrets/1234/payload", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "top secret")
+	})
+
+	sc, records := setup(t, logging.WithRedactedHeaders("X-Custom"))
+	sc.MoreHeaders = map[string]string{"X-Custom": "custom"}
+
+	resp, err := sc.Get(context.TODO(), sc.ServiceURL("secrets", "1234", "payload"), nil, &gophercloud.RequestOpts{
+		KeepResponseBody: true,
+	})
+	th.AssertNoErr(t, err)
+	defer resp.Body.Close()
+
+	rs := records()
+	th.CheckEquals(t, logging.Redacted, rs[0]["headers"].(map[string]any)["X-Custom"])
+	th.CheckEquals(t, logging.Redacted, rs[1]["body"])
+}
+
+func TestLogDisabledLevel(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	p := &gophercloud.ProviderClient{}
+	logging.Attach(p, logger)
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 0, buf.Len())
+}
@@ -0,0 +1,235 @@
+package gophercloud
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MicroversionRange is the range of microversions supported by a service
+// endpoint, as advertised in its version document.
+type MicroversionRange struct {
+	// Min is the lowest supported microversion, e.g. "2.1".
+	Min string
+
+	// Max is the highest supported microversion, e.g. "2.96".
+	Max string
+}
+
+// Contains reports whether version is within the range.
+func (r MicroversionRange) Contains(version string) (bool, error) {
+	lower, err := compareMicroversions(version, r.Min)
+	if err != nil {
+		return false, err
+	}
+	upper, err := compareMicroversions(version, r.Max)
+	if err != nil {
+		return false, err
+	}
+	return lower >= 0 && upper <= 0, nil
+}
+
+// ErrMicroversionNotSupported is returned by ServiceClient.Request when a
+// request declares a MinMicroversion that cannot be used: either the service
+// client is set to a lower microversion and negotiation is disabled, or the
+// service does not support it.
+type ErrMicroversionNotSupported struct {
+	BaseError
+
+	// ServiceType is the type of the service client, e.g. compute.
+	ServiceType string
+
+	// Required is the MinMicroversion of the request.
+	Required string
+
+	// Current is the microversion the service client is set to.
+	Current string
+
+	// Supported is the range of microversions supported by the service. It is
+	// nil when microversion negotiation is disabled.
+	Supported *MicroversionRange
+}
+
+func (e ErrMicroversionNotSupported) Error() string {
+	if e.Supported != nil {
+		e.DefaultErrString = fmt.Sprintf(
+			"The request requires %s microversion %s or later, but the service only supports microversions %s to %s",
+			e.ServiceType, e.Required, e.Supported.Min, e.Supported.Max,
+		)
+	} else {
+		current := e.Current
+		if current == "" {
+			current = "the default microversion"
+		}
+		e.DefaultErrString = fmt.Sprintf(
+			"The request requires %s microversion %s or later, but the service client is set to %s",
+			e.ServiceType, e.Required, current,
+		)
+	}
+	return e.choseErrString()
+}
+
+// SupportedMicroversions returns the range of microversions supported by the
+// service endpoint. When the provider has NegotiateMicroversions set, the
+// range is only queried once per endpoint, and cached on the ProviderClient.
+func (client *ServiceClient) SupportedMicroversions(ctx context.Context) (MicroversionRange, error) {
+	if client.NegotiateMicroversions {
+		if r, ok := client.cachedMicroversions(client.Endpoint); ok {
+			return r, nil
+		}
+	}
+
+	type valueResp struct {
+		Version    string `json:"version"`
+		MinVersion string `json:"min_version"`
+	}
+
+	type response struct {
+		Version  valueResp   `json:"version"`
+		Versions []valueResp `json:"versions"`
+	}
+
+	var resp response
+	_, err := client.Get(ctx, client.Endpoint, &resp, &RequestOpts{
+		OkCodes: []int{200, 300},
+	})
+	if err != nil {
+		return MicroversionRange{}, err
+	}
+
+	version := resp.Version
+	if len(resp.Versions) > 0 {
+		// We are dealing with an unversioned endpoint
+		// We only handle the case when there is exactly one, and assume it is the correct one
+		if len(resp.Versions) > 1 {
+			return MicroversionRange{}, fmt.Errorf("unversioned endpoint with multiple alternatives not supported")
+		}
+		version = resp.Versions[0]
+	}
+
+	if version.MinVersion == "" && version.Version == "" {
+		return MicroversionRange{}, fmt.Errorf("microversions not supported by ServiceClient Endpoint")
+	}
+
+	r := MicroversionRange{Min: version.MinVersion, Max: version.Version}
+	if _, err := compareMicroversions(r.Min, r.Max); err != nil {
+		return MicroversionRange{}, err
+	}
+
+	if client.NegotiateMicroversions {
+		client.cacheMicroversions(client.Endpoint, r)
+	}
+	return r, nil
+}
+
+// requestMicroversion returns the microversion to send a request with, given
+// the minimum microversion it requires.
+func (client *ServiceClient) requestMicroversion(ctx context.Context, required string) (string, error) {
+	if required == "" {
+		return client.Microversion, nil
+	}
+
+	// The latest microversion meets any minimum.
+	if client.Microversion == "latest" {
+		return client.Microversion, nil
+	}
+
+	if client.Microversion != "" {
+		c, err := compareMicroversions(client.Microversion, required)
+		if err != nil {
+			return "", err
+		}
+		if c >= 0 {
+			return client.Microversion, nil
+		}
+	}
+
+	if !client.NegotiateMicroversions {
+		return "", ErrMicroversionNotSupported{
+			ServiceType: client.Type,
+			Required:    required,
+			Current:     client.Microversion,
+		}
+	}
+
+	supported, err := client.SupportedMicroversions(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine supported microversions: %w", err)
+	}
+
+	if c, err := compareMicroversions(required, supported.Max); err != nil {
+		return "", err
+	} else if c > 0 {
+		return "", ErrMicroversionNotSupported{
+			ServiceType: client.Type,
+			Required:    required,
+			Current:     client.Microversion,
+			Supported:   &supported,
+		}
+	}
+
+	// The service may have dropped support for microversions older than
+	// its minimum.
+	if c, _ := compareMicroversions(required, supported.Min); c < 0 {
+		return supported.Min, nil
+	}
+	return required, nil
+}
+
+func (client *ProviderClient) cachedMicroversions(endpoint string) (MicroversionRange, bool) {
+	if client.mut != nil {
+		client.mut.RLock()
+		defer client.mut.RUnlock()
+	}
+	r, ok := client.microversions[endpoint]
+	return r, ok
+}
+
+func (client *ProviderClient) cacheMicroversions(endpoint string, r MicroversionRange) {
+	if client.mut != nil {
+		client.mut.Lock()
+		defer client.mut.Unlock()
+	}
+	if client.microversions == nil {
+		client.microversions = make(map[string]MicroversionRange)
+	}
+	client.microversions[endpoint] = r
+}
+
+// compareMicroversions returns -1, 0 or 1 depending on whether a is lower
+// than, equal to, or higher than b.
+func compareMicroversions(a, b string) (int, error) {
+	aMajor, aMinor, err := ParseMicroversion(a)
+	if err != nil {
+		return 0, err
+	}
+	bMajor, bMinor, err := ParseMicroversion(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if aMajor != bMajor {
+		return cmp.Compare(aMajor, bMajor), nil
+	}
+	return cmp.Compare(aMinor, bMinor), nil
+}
+
+// ParseMicroversion parses the version major.minor into separate integers major and minor.
+// For example, "2.53" becomes 2 and 53.
+func ParseMicroversion(version string) (major int, minor int, err error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid microversion format: %q", version)
+	}
+	major, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	minor, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return major, minor, nil
+}
@@ -0,0 +1,102 @@
+package gophercloud
+
+import (
+	"errors"
+	"net/http"
+)
+
+// RequestInfo describes a single HTTP request issued by a ProviderClient. It
+// is handed to every Middleware, and carries the Gophercloud context that is
+// lost once the request reaches the http.RoundTripper.
+type RequestInfo struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// URL is the URL of the request, as passed to ProviderClient.Request.
+	URL string
+
+	// Options are the RequestOpts the request was issued with. Middleware
+	// should treat them as read-only.
+	Options *RequestOpts
+
+	// ServiceType is the type of the ServiceClient that issued the request
+	// (e.g. compute, network). It is empty when ProviderClient.Request was
+	// called directly.
+	ServiceType string
+
+	// Microversion is the microversion requested by the ServiceClient that
+	// issued the request, if any.
+	Microversion string
+
+	// Retries is the number of times the request has already been retried
+	// because of a RetryFunc or RetryBackoffFunc. It is 0 on the first attempt.
+	Retries uint
+
+	// Reauthenticated is true when the request is being replayed after
+	// a successful reauthentication caused by a 401 response.
+	Reauthenticated bool
+}
+
+// RequestFunc sends a single HTTP request and returns its response. It has the
+// same semantics as http.Client.Do.
+type RequestFunc func(req *http.Request, info *RequestInfo) (*http.Response, error)
+
+// Middleware wraps the RequestFunc used by a ProviderClient to send every HTTP
+// request, including each retry and the replay after a reauthentication. A
+// Middleware may inspect or modify the request before calling next, inspect
+// or replace the response afterwards, or return early without calling next.
+// It must return either a response or an error.
+//
+// Middleware runs after Gophercloud has populated the request headers, so the
+// request already carries the authentication token, and before the response
+// status is checked against the expected OkCodes.
+type Middleware func(next RequestFunc) RequestFunc
+
+// RequestHook returns a Middleware that calls f before each request is sent.
+// If f returns an error, the request is not sent and the error is returned to
+// the caller.
+func RequestHook(f func(req *http.Request, info *RequestInfo) error) Middleware {
+	return func(next RequestFunc) RequestFunc {
+		return func(req *http.Request, info *RequestInfo) (*http.Response, error) {
+			if err := f(req, info); err != nil {
+				return nil, err
+			}
+			return next(req, info)
+		}
+	}
+}
+
+// ResponseHook returns a Middleware that calls f after each request has been
+// sent, with the response and error returned by the HTTP client.
+func ResponseHook(f func(resp *http.Response, err error, info *RequestInfo)) Middleware {
+	return func(next RequestFunc) RequestFunc {
+		return func(req *http.Request, info *RequestInfo) (*http.Response, error) {
+			resp, err := next(req, info)
+			f(resp, err, info)
+			return resp, err
+		}
+	}
+}
+
+// Use appends middleware to the ProviderClient. The first Middleware added is
+// the outermost one: it sees the request first and the response last.
+//
+// Use is not safe to call concurrently with requests issued by the client.
+func (client *ProviderClient) Use(middleware ...Middleware) {
+	client.Middleware = append(client.Middleware, middleware...)
+}
+
+// send issues req through the Middleware chain and the HTTPClient.
+func (client *ProviderClient) send(req *http.Request, info *RequestInfo) (*http.Response, error) {
+	var do RequestFunc = func(req *http.Request, _ *RequestInfo) (*http.Response, error) {
+		return client.HTTPClient.Do(req)
+	}
+	for i := len(client.Middleware) - 1; i >= 0; i-- {
+		do = client.Middleware[i](do)
+	}
+	resp, err := do(req, info)
+	if resp == nil && err == nil {
+		return nil, errors.New("middleware returned neither a response nor an error")
+	}
+	return resp, err
+}
@@ -10,6 +10,7 @@ import (
 	tokens2 "github.com/gophercloud/gophercloud/v2/openstack/identity/v2/tokens"
 	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/ec2tokens"
 	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/oauth1"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/oidc"
 	tokens3 "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
 	"github.com/gophercloud/gophercloud/v2/openstack/utils"
 )
@@ -86,6 +87,11 @@ func AuthenticatedClient(ctx context.Context, options gophercloud.AuthOptions) (
 
 // Authenticate authenticates or re-authenticates against the most
 // recent identity service supported at the provided endpoint.
+//
+// When options has a TokenCache, a cached token is used if it remains valid
+// for long enough and Keystone still accepts it. Otherwise, a new token is
+// requested and cached. See NewFileTokenCache. The TokenCache is not used
+// when options has a Passcode, which changes with each login.
 func Authenticate(ctx context.Context, client *gophercloud.ProviderClient, options gophercloud.AuthOptions) error {
 	versions := []*utils.Version{
 		{ID: v2, Priority: 20, Suffix: "/v2.0/"},
@@ -99,8 +105,14 @@ func Authenticate(ctx context.Context, client *gophercloud.ProviderClient, optio
 
 	switch chosen.ID {
 	case v2:
+		if options.OIDC != nil {
+			return fmt.Errorf("OpenID Connect authentication requires the identity v3 API")
+		}
 		return v2auth(ctx, client, endpoint, &options, gophercloud.EndpointOpts{})
 	case v3:
+		if options.TokenCache != nil && options.Passcode == "" {
+			return v3authCached(ctx, client, endpoint, &options, gophercloud.EndpointOpts{})
+		}
 		return v3auth(ctx, client, endpoint, &options, gophercloud.EndpointOpts{})
 	default:
 		// The switch statement must be out of date from the versions list.
@@ -184,6 +196,10 @@ func v3auth(ctx context.Context, client *gophercloud.ProviderClient, endpoint st
 		v3Client.Endpoint = endpoint
 	}
 
+	if ao, ok := opts.(*gophercloud.AuthOptions); ok && ao.OIDC != nil {
+		opts = oidcAuthOptions(ao)
+	}
+
 	var catalog *tokens3.ServiceCatalog
 
 	var tokenID string
@@ -221,11 +237,13 @@ func v3auth(ctx context.Context, client *gophercloud.ProviderClient, endpoint st
 		}
 	} else {
 		var result tokens3.CreateResult
-		switch opts.(type) {
+		switch v := opts.(type) {
 		case *ec2tokens.AuthOptions:
 			result = ec2tokens.Create(ctx, v3Client, opts)
 		case *oauth1.AuthOptions:
 			result = oauth1.Create(ctx, v3Client, opts)
+		case *oidc.AuthOptions:
+			result = oidc.Create(ctx, v3Client, *v)
 		default:
 			result = tokens3.Create(ctx, v3Client, opts)
 		}
@@ -242,45 +260,9 @@ func v3auth(ctx context.Context, client *gophercloud.ProviderClient, endpoint st
 	}
 
 	if opts.CanReauth() {
-		// here we're creating a throw-away client (tac). it's a copy of the user's provider client, but
-		// with the token and reauth func zeroed out. combined with setting `AllowReauth` to `false`,
-		// this should retry authentication only once
-		tac := *client
-		tac.SetThrowaway(true)
-		tac.ReauthFunc = nil
-		err = tac.SetTokenAndAuthResult(nil)
-		if err != nil {
+		if err := setV3ReauthFunc(client, endpoint, opts, eo); err != nil {
 			return err
 		}
-		var tao tokens3.AuthOptionsBuilder
-		switch ot := opts.(type) {
-		case *gophercloud.AuthOptions:
-			o := *ot
-			o.AllowReauth = false
-			tao = &o
-		case *tokens3.AuthOptions:
-			o := *ot
-			o.AllowReauth = false
-			tao = &o
-		case *ec2tokens.AuthOptions:
-			o := *ot
-			o.AllowReauth = false
-			tao = &o
-		case *oauth1.AuthOptions:
-			o := *ot
-			o.AllowReauth = false
-			tao = &o
-		default:
-			tao = opts
-		}
-		client.ReauthFunc = func(ctx context.Context) error {
-			err := v3auth(ctx, &tac, endpoint, tao, eo)
-			if err != nil {
-				return err
-			}
-			client.CopyTokenFrom(&tac)
-			return nil
-		}
 	}
 	client.EndpointLocator = func(opts gophercloud.EndpointOpts) (string, error) {
 		return V3EndpointURL(catalog, opts)
@@ -289,6 +271,79 @@ func v3auth(ctx context.Context, client *gophercloud.ProviderClient, endpoint st
 	return nil
 }
 
+// setV3ReauthFunc sets a ReauthFunc on client, which authenticates again with
+// opts.
+func setV3ReauthFunc(client *gophercloud.ProviderClient, endpoint string, opts tokens3.AuthOptionsBuilder, eo gophercloud.EndpointOpts) error {
+	// here we're creating a throw-away client (tac). it's a copy of the user's provider client, but
+	// with the token and reauth func zeroed out. combined with setting `AllowReauth` to `false`,
+	// this should retry authentication only once
+	tac := *client
+	tac.SetThrowaway(true)
+	tac.ReauthFunc = nil
+	err := tac.SetTokenAndAuthResult(nil)
+	if err != nil {
+		return err
+	}
+	var tao tokens3.AuthOptionsBuilder
+	switch ot := opts.(type) {
+	case *gophercloud.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		tao = &o
+	case *tokens3.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		tao = &o
+	case *ec2tokens.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		tao = &o
+	case *oauth1.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		tao = &o
+	case *oidc.AuthOptions:
+		o := *ot
+		o.AllowReauth = false
+		tao = &o
+	default:
+		tao = opts
+	}
+	client.ReauthFunc = func(ctx context.Context) error {
+		err := v3auth(ctx, &tac, endpoint, tao, eo)
+		if err != nil {
+			return err
+		}
+		client.CopyTokenFrom(&tac)
+		return nil
+	}
+	return nil
+}
+
+// oidcAuthOptions converts AuthOptions with OIDC set to the options of the
+// oidc package, deriving the scope like AuthOptions.ToTokenV3ScopeMap does.
+func oidcAuthOptions(ao *gophercloud.AuthOptions) *oidc.AuthOptions {
+	var scope tokens3.Scope
+	switch {
+	case ao.Scope != nil:
+		scope = tokens3.Scope(*ao.Scope)
+	case ao.TenantID != "":
+		scope.ProjectID = ao.TenantID
+	case ao.TenantName != "":
+		scope.ProjectName = ao.TenantName
+		scope.DomainID = ao.DomainID
+		scope.DomainName = ao.DomainName
+	}
+
+	return &oidc.AuthOptions{
+		OIDCAuthOptions: *ao.OIDC,
+		Username:        ao.Username,
+		Password:        ao.Password,
+		Scope:           scope,
+		AllowReauth:     ao.AllowReauth,
+	}
+}
+
 // NewIdentityV2 creates a ServiceClient that may be used to interact with the
 // v2 identity service.
 func NewIdentityV2(client *gophercloud.ProviderClient, eo gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error) {
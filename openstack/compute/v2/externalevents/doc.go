@@ -0,0 +1,34 @@
+/*
+Package externalevents sends external events about servers to the Compute
+service (os-server-external-events). This is an administrative API, which is
+usually called by other services to notify the Compute service that, for
+example, a port was plugged or a volume was extended.
+
+Some event names require a later microversion, e.g. "2.51" for
+volume-extended; Create declares it as the minimum microversion of the
+request.
+
+Example to Send an External Event
+
+	createOpts := externalevents.CreateOpts{
+		Events: []externalevents.EventOpts{
+			{
+				Name:       externalevents.NetworkVIFPlugged,
+				ServerUUID: serverID,
+				Tag:        portID,
+			},
+		},
+	}
+
+	events, err := externalevents.Create(context.TODO(), computeClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, event := range events {
+		if event.Code != 200 {
+			fmt.Printf("event %s for %s was not accepted: %d\n", event.Name, event.ServerUUID, event.Code)
+		}
+	}
+*/
+package externalevents
@@ -0,0 +1,110 @@
+package externalevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// EventName is the name of an external event.
+type EventName string
+
+const (
+	NetworkChanged          EventName = "network-changed"
+	NetworkVIFPlugged       EventName = "network-vif-plugged"
+	NetworkVIFUnplugged     EventName = "network-vif-unplugged"
+	NetworkVIFDeleted       EventName = "network-vif-deleted"
+	VolumeExtended          EventName = "volume-extended"
+	PowerUpdate             EventName = "power-update"
+	AcceleratorRequestBound EventName = "accelerator-request-bound"
+	VolumeReimaged          EventName = "volume-reimaged"
+)
+
+// eventMinorVersions are the 2.x microversions which introduced the event
+// names that are not available in the base API.
+var eventMinorVersions = map[EventName]int{
+	VolumeExtended:          51,
+	PowerUpdate:             76,
+	AcceleratorRequestBound: 82,
+	VolumeReimaged:          93,
+}
+
+// EventStatus is the status of an external event.
+type EventStatus string
+
+const (
+	EventStatusCompleted  EventStatus = "completed"
+	EventStatusFailed     EventStatus = "failed"
+	EventStatusInProgress EventStatus = "in-progress"
+)
+
+// EventOpts is a single event to send.
+type EventOpts struct {
+	// Name is the name of the event.
+	Name EventName `json:"name" required:"true"`
+
+	// ServerUUID is the UUID of the server the event is about.
+	ServerUUID string `json:"server_uuid" required:"true"`
+
+	// Status is the status of the event. It defaults to completed.
+	Status EventStatus `json:"status,omitempty"`
+
+	// Tag is a resource-specific tag, e.g. the port ID for network events,
+	// the volume ID for volume events, or the target power state for
+	// power-update.
+	Tag string `json:"tag,omitempty"`
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToExternalEventsCreateMap() (map[string]any, error)
+}
+
+// CreateOpts specifies the events to send.
+type CreateOpts struct {
+	// Events are the events to send.
+	Events []EventOpts `json:"events" required:"true"`
+}
+
+// ToExternalEventsCreateMap constructs a request body from CreateOpts.
+func (opts CreateOpts) ToExternalEventsCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// minMicroversion returns the lowest microversion supporting all the event
+// names of opts, or an empty string if the base API suffices.
+func (opts CreateOpts) minMicroversion() string {
+	var minor int
+	for _, e := range opts.Events {
+		minor = max(minor, eventMinorVersions[e.Name])
+	}
+	if minor == 0 {
+		return ""
+	}
+	return fmt.Sprintf("2.%d", minor)
+}
+
+// Create sends external events to the Compute service. This is an
+// administrative API which is usually called by other services, such as the
+// Networking service. When CreateOpts contain events introduced by a later
+// microversion, the request requires at least that microversion.
+func Create(ctx context.Context, client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToExternalEventsCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	reqOpts := &gophercloud.RequestOpts{
+		OkCodes: []int{200, 207},
+	}
+	if o, ok := opts.(CreateOpts); ok {
+		reqOpts.MinMicroversion = o.minMicroversion()
+	}
+
+	resp, err := client.Post(ctx, createURL(client), b, &r.Body, reqOpts)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,38 @@
+package externalevents
+
+import "github.com/gophercloud/gophercloud/v2"
+
+// Event is the outcome of a single external event.
+type Event struct {
+	// Name is the name of the event.
+	Name EventName `json:"name"`
+
+	// ServerUUID is the UUID of the server the event is about.
+	ServerUUID string `json:"server_uuid"`
+
+	// Status is the status of the event.
+	Status EventStatus `json:"status"`
+
+	// Tag is the resource-specific tag of the event.
+	Tag string `json:"tag"`
+
+	// Code is the HTTP status code of the event: 200 when it was accepted,
+	// 400, 404 or 422 when it was not.
+	Code int `json:"code"`
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a slice of Events. The response status is 207
+// when some of the events were not accepted; check the Code of each Event.
+type CreateResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a CreateResult as a slice of Events.
+func (r CreateResult) Extract() ([]Event, error) {
+	var s struct {
+		Events []Event `json:"events"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Events, err
+}
@@ -0,0 +1,2 @@
+// externalevents unit tests
+package testing
@@ -0,0 +1,86 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/externalevents"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+// CreateRequest is a sample request to send two events.
+const CreateRequest = `
+{
+    "events": [
+        {
+            "name": "network-vif-plugged",
+            "server_uuid": "3df201cf-2451-44f2-8d25-a4ca826fc1f3",
+            "tag": "foo"
+        },
+        {
+            "name": "volume-extended",
+            "server_uuid": "ae0ea6f7-1f58-4d4c-8d4f-7b8a2b6e0f5e",
+            "status": "completed",
+            "tag": "bar"
+        }
+    ]
+}
+`
+
+// CreateOutput is a sample response to CreateRequest, in which the second
+// event was not accepted.
+const CreateOutput = `
+{
+    "events": [
+        {
+            "code": 200,
+            "name": "network-vif-plugged",
+            "server_uuid": "3df201cf-2451-44f2-8d25-a4ca826fc1f3",
+            "status": "completed",
+            "tag": "foo"
+        },
+        {
+            "code": 404,
+            "name": "volume-extended",
+            "server_uuid": "ae0ea6f7-1f58-4d4c-8d4f-7b8a2b6e0f5e",
+            "status": "completed",
+            "tag": "bar"
+        }
+    ]
+}
+`
+
+// ExpectedEvents are the events in CreateOutput.
+var ExpectedEvents = []externalevents.Event{
+	{
+		Name:       externalevents.NetworkVIFPlugged,
+		ServerUUID: "3df201cf-2451-44f2-8d25-a4ca826fc1f3",
+		Status:     externalevents.EventStatusCompleted,
+		Tag:        "foo",
+		Code:       200,
+	},
+	{
+		Name:       externalevents.VolumeExtended,
+		ServerUUID: "ae0ea6f7-1f58-4d4c-8d4f-7b8a2b6e0f5e",
+		Status:     externalevents.EventStatusCompleted,
+		Tag:        "bar",
+		Code:       404,
+	},
+}
+
+// HandleCreateSuccessfully configures the test server to respond to a Create
+// request with a partial success.
+func HandleCreateSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/os-server-external-events", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Nova-API-Version", "2.51")
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultiStatus)
+		fmt.Fprint(w, CreateOutput)
+	})
+}
@@ -0,0 +1,57 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/externalevents"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+var createOpts = externalevents.CreateOpts{
+	Events: []externalevents.EventOpts{
+		{
+			Name:       externalevents.NetworkVIFPlugged,
+			ServerUUID: "3df201cf-2451-44f2-8d25-a4ca826fc1f3",
+			Tag:        "foo",
+		},
+		{
+			Name:       externalevents.VolumeExtended,
+			ServerUUID: "ae0ea6f7-1f58-4d4c-8d4f-7b8a2b6e0f5e",
+			Status:     externalevents.EventStatusCompleted,
+			Tag:        "bar",
+		},
+	},
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateSuccessfully(t)
+
+	sc := client.ServiceClient()
+	sc.Type = "compute"
+	sc.Microversion = "2.51"
+
+	actual, err := externalevents.Create(context.TODO(), sc, createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedEvents, actual)
+}
+
+func TestCreateMicroversionTooLow(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	sc := client.ServiceClient()
+	sc.Type = "compute"
+
+	_, err := externalevents.Create(context.TODO(), sc, createOpts).Extract()
+	var mvErr gophercloud.ErrMicroversionNotSupported
+	if !errors.As(err, &mvErr) {
+		t.Fatalf("Expected ErrMicroversionNotSupported, got %v", err)
+	}
+	th.CheckEquals(t, "2.51", mvErr.Required)
+}
@@ -0,0 +1,64 @@
+/*
+Package migrations lists the migrations of servers and controls in-progress
+live migrations.
+
+Example to List Migrations of a Host
+
+	listOpts := migrations.ListOpts{
+		SourceCompute: "compute-01",
+		MigrationType: migrations.MigrationTypeLiveMigration,
+	}
+
+	allPages, err := migrations.List(computeClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allMigrations, err := migrations.ExtractMigrations(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, migration := range allMigrations {
+		fmt.Printf("%+v\n", migration)
+	}
+
+Example to Track the Live Migration of a Server
+
+	computeClient.Microversion = "2.23"
+
+	allPages, err := migrations.ListServerMigrations(computeClient, serverID).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	serverMigrations, err := migrations.ExtractServerMigrations(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, migration := range serverMigrations {
+		if migration.MemoryRemainingBytes != nil {
+			fmt.Printf("%s: %d bytes of memory remaining\n", migration.Status, *migration.MemoryRemainingBytes)
+		}
+	}
+
+Example to Force a Live Migration to Complete
+
+	computeClient.Microversion = "2.22"
+
+	err := migrations.ForceComplete(context.TODO(), computeClient, serverID, migrationID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Abort a Live Migration
+
+	computeClient.Microversion = "2.24"
+
+	err := migrations.Abort(context.TODO(), computeClient, serverID, migrationID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package migrations
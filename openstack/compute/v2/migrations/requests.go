@@ -0,0 +1,173 @@
+package migrations
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+const (
+	// forceCompleteMicroversion is the lowest microversion supporting
+	// ForceComplete.
+	forceCompleteMicroversion = "2.22"
+
+	// serverMigrationsMicroversion is the lowest microversion exposing the
+	// migrations of a server.
+	serverMigrationsMicroversion = "2.23"
+
+	// abortMicroversion is the lowest microversion supporting Abort.
+	abortMicroversion = "2.24"
+)
+
+// MigrationType is the type of a migration.
+type MigrationType string
+
+const (
+	MigrationTypeMigration     MigrationType = "migration"
+	MigrationTypeLiveMigration MigrationType = "live-migration"
+	MigrationTypeEvacuation    MigrationType = "evacuation"
+	MigrationTypeResize        MigrationType = "resize"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	ToMigrationListQuery() (string, error)
+}
+
+// ListOpts represents options used to filter migration results in a List
+// request.
+type ListOpts struct {
+	// Hidden filters the response by the hidden status of the migrations.
+	Hidden *bool `q:"hidden"`
+
+	// Host filters the response by source or destination compute host.
+	Host string `q:"host"`
+
+	// InstanceUUID filters the response by the UUID of a server.
+	InstanceUUID string `q:"instance_uuid"`
+
+	// MigrationType filters the response by the type of migration.
+	// This requires microversion 2.23 or later.
+	MigrationType MigrationType `q:"migration_type"`
+
+	// SourceCompute filters the response by the source compute host.
+	SourceCompute string `q:"source_compute"`
+
+	// Status filters the response by the status of the migrations.
+	Status string `q:"status"`
+
+	// Limit is an integer value to limit the results to return.
+	// This requires microversion 2.59 or later.
+	Limit int `q:"limit"`
+
+	// Marker is the UUID of the last-seen migration.
+	// This requires microversion 2.59 or later.
+	Marker string `q:"marker"`
+
+	// ChangesSince filters the response by migrations updated after the
+	// given time.
+	// This requires microversion 2.59 or later.
+	ChangesSince *time.Time `q:"changes-since"`
+
+	// ChangesBefore filters the response by migrations updated before the
+	// given time.
+	// This requires microversion 2.66 or later.
+	ChangesBefore *time.Time `q:"changes-before"`
+
+	// UserID filters the response by the user which initiated the
+	// migrations.
+	// This requires microversion 2.80 or later.
+	UserID string `q:"user_id"`
+
+	// ProjectID filters the response by the project of the migrated
+	// servers.
+	// This requires microversion 2.80 or later.
+	ProjectID string `q:"project_id"`
+}
+
+// ToMigrationListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToMigrationListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+
+	params := q.Query()
+
+	if opts.ChangesSince != nil {
+		params.Add("changes-since", opts.ChangesSince.UTC().Format(time.RFC3339))
+	}
+
+	if opts.ChangesBefore != nil {
+		params.Add("changes-before", opts.ChangesBefore.UTC().Format(time.RFC3339))
+	}
+
+	q = &url.URL{RawQuery: params.Encode()}
+	return q.String(), nil
+}
+
+// List makes a request against the API to list the migrations of all
+// servers.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToMigrationListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return MigrationPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListServerMigrations makes a request against the API to list the
+// in-progress live migrations of a server.
+// This requires microversion 2.23 or later.
+func ListServerMigrations(client *gophercloud.ServiceClient, serverID string) pagination.Pager {
+	pager := pagination.NewPager(client, listServerMigrationsURL(client, serverID), func(r pagination.PageResult) pagination.Page {
+		return ServerMigrationPage{pagination.SinglePageBase(r)}
+	})
+	pager.MinMicroversion = serverMigrationsMicroversion
+	return pager
+}
+
+// GetServerMigration makes a request against the API to get an in-progress
+// live migration of a server.
+// This requires microversion 2.23 or later.
+func GetServerMigration(ctx context.Context, client *gophercloud.ServiceClient, serverID string, migrationID int) (r GetServerMigrationResult) {
+	resp, err := client.Get(ctx, serverMigrationURL(client, serverID, migrationID), &r.Body, &gophercloud.RequestOpts{
+		OkCodes:         []int{200},
+		MinMicroversion: serverMigrationsMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ForceComplete forces an in-progress live migration of a server to
+// complete, by pausing the server or switching to post-copy.
+// This requires microversion 2.22 or later.
+func ForceComplete(ctx context.Context, client *gophercloud.ServiceClient, serverID string, migrationID int) (r ForceCompleteResult) {
+	resp, err := client.Post(ctx, serverMigrationActionURL(client, serverID, migrationID), map[string]any{"force_complete": nil}, nil, &gophercloud.RequestOpts{
+		OkCodes:         []int{202},
+		MinMicroversion: forceCompleteMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Abort aborts an in-progress live migration of a server.
+// This requires microversion 2.24 or later.
+func Abort(ctx context.Context, client *gophercloud.ServiceClient, serverID string, migrationID int) (r AbortResult) {
+	resp, err := client.Delete(ctx, serverMigrationURL(client, serverID, migrationID), &gophercloud.RequestOpts{
+		OkCodes:         []int{202},
+		MinMicroversion: abortMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
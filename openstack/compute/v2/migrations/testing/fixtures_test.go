@@ -0,0 +1,214 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/migrations"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+// ListOutput is a sample first page of migrations. The next page link is
+// filled in by the handler.
+const ListOutput = `
+{
+    "migrations": [
+        {
+            "created_at": "2024-01-29T11:42:02.000000",
+            "dest_compute": "compute2",
+            "dest_host": "1.2.3.4",
+            "dest_node": "node2",
+            "id": 1234,
+            "instance_uuid": "8600d31b-d1a1-4632-b2ff-45c2be1a70ff",
+            "migration_type": "live-migration",
+            "new_instance_type_id": 2,
+            "old_instance_type_id": 1,
+            "source_compute": "compute1",
+            "source_node": "node1",
+            "status": "running",
+            "updated_at": "2024-01-29T11:42:10.000000",
+            "uuid": "12341d4b-346a-40d0-83c6-5f4f6892b650",
+            "user_id": "ef9d34b4-45d0-4530-871b-3fb535988394",
+            "project_id": "011ee9f4-8f16-4c38-8633-a254d420fd54"
+        }
+    ],
+    "migrations_links": [
+        {
+            "href": "%s/os-migrations?limit=1&marker=12341d4b-346a-40d0-83c6-5f4f6892b650",
+            "rel": "next"
+        }
+    ]
+}
+`
+
+// ServerMigrationsOutput is a sample list of in-progress migrations of a
+// server.
+const ServerMigrationsOutput = `
+{
+    "migrations": [
+        {
+            "created_at": "2024-01-29T11:42:02.000000",
+            "dest_compute": "compute2",
+            "dest_host": "1.2.3.4",
+            "dest_node": "node2",
+            "id": 1234,
+            "disk_processed_bytes": 0,
+            "disk_remaining_bytes": 0,
+            "disk_total_bytes": 0,
+            "memory_processed_bytes": 1000,
+            "memory_remaining_bytes": 3000,
+            "memory_total_bytes": 4000,
+            "server_uuid": "8600d31b-d1a1-4632-b2ff-45c2be1a70ff",
+            "source_compute": "compute1",
+            "source_node": "node1",
+            "status": "running",
+            "updated_at": "2024-01-29T11:42:10.000000",
+            "uuid": "12341d4b-346a-40d0-83c6-5f4f6892b650"
+        }
+    ]
+}
+`
+
+// ServerMigrationOutput is a sample in-progress migration of a server.
+const ServerMigrationOutput = `
+{
+    "migration": {
+        "created_at": "2024-01-29T11:42:02.000000",
+        "dest_compute": "compute2",
+        "dest_host": "1.2.3.4",
+        "dest_node": "node2",
+        "id": 1234,
+        "disk_processed_bytes": 0,
+        "disk_remaining_bytes": 0,
+        "disk_total_bytes": 0,
+        "memory_processed_bytes": 1000,
+        "memory_remaining_bytes": 3000,
+        "memory_total_bytes": 4000,
+        "server_uuid": "8600d31b-d1a1-4632-b2ff-45c2be1a70ff",
+        "source_compute": "compute1",
+        "source_node": "node1",
+        "status": "running",
+        "updated_at": "2024-01-29T11:42:10.000000",
+        "uuid": "12341d4b-346a-40d0-83c6-5f4f6892b650"
+    }
+}
+`
+
+// ExpectedMigration is the migration in ListOutput.
+var ExpectedMigration = migrations.Migration{
+	ID:                1234,
+	UUID:              "12341d4b-346a-40d0-83c6-5f4f6892b650",
+	InstanceUUID:      "8600d31b-d1a1-4632-b2ff-45c2be1a70ff",
+	MigrationType:     migrations.MigrationTypeLiveMigration,
+	Status:            "running",
+	SourceCompute:     "compute1",
+	SourceNode:        "node1",
+	DestCompute:       "compute2",
+	DestHost:          "1.2.3.4",
+	DestNode:          "node2",
+	OldInstanceTypeID: 1,
+	NewInstanceTypeID: 2,
+	UserID:            "ef9d34b4-45d0-4530-871b-3fb535988394",
+	ProjectID:         "011ee9f4-8f16-4c38-8633-a254d420fd54",
+	CreatedAt:         time.Date(2024, 1, 29, 11, 42, 2, 0, time.UTC),
+	UpdatedAt:         time.Date(2024, 1, 29, 11, 42, 10, 0, time.UTC),
+}
+
+var (
+	zero        int64 = 0
+	processed   int64 = 1000
+	remaining   int64 = 3000
+	memoryTotal int64 = 4000
+)
+
+// ExpectedServerMigration is the migration in ServerMigrationsOutput and
+// ServerMigrationOutput.
+var ExpectedServerMigration = migrations.ServerMigration{
+	ID:                   1234,
+	UUID:                 "12341d4b-346a-40d0-83c6-5f4f6892b650",
+	ServerUUID:           "8600d31b-d1a1-4632-b2ff-45c2be1a70ff",
+	Status:               "running",
+	SourceCompute:        "compute1",
+	SourceNode:           "node1",
+	DestCompute:          "compute2",
+	DestHost:             "1.2.3.4",
+	DestNode:             "node2",
+	MemoryTotalBytes:     &memoryTotal,
+	MemoryProcessedBytes: &processed,
+	MemoryRemainingBytes: &remaining,
+	DiskTotalBytes:       &zero,
+	DiskProcessedBytes:   &zero,
+	DiskRemainingBytes:   &zero,
+	CreatedAt:            time.Date(2024, 1, 29, 11, 42, 2, 0, time.UTC),
+	UpdatedAt:            time.Date(2024, 1, 29, 11, 42, 10, 0, time.UTC),
+}
+
+// HandleListSuccessfully sets up the test server to respond to a List
+// request with two pages, the second one being empty.
+func HandleListSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/os-migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse request form %v", err)
+		}
+		switch r.Form.Get("marker") {
+		case "":
+			th.CheckEquals(t, "compute1", r.Form.Get("source_compute"))
+			th.CheckEquals(t, "live-migration", r.Form.Get("migration_type"))
+			th.CheckEquals(t, "2024-01-29T00:00:00Z", r.Form.Get("changes-since"))
+			fmt.Fprintf(w, ListOutput, th.Server.URL)
+		case "12341d4b-346a-40d0-83c6-5f4f6892b650":
+			fmt.Fprint(w, `{"migrations": []}`)
+		default:
+			t.Fatalf("Unexpected marker: [%s]", r.Form.Get("marker"))
+		}
+	})
+}
+
+// HandleListServerMigrationsSuccessfully sets up the test server to respond
+// to a ListServerMigrations request.
+func HandleListServerMigrationsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/8600d31b-d1a1-4632-b2ff-45c2be1a70ff/migrations", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ServerMigrationsOutput)
+	})
+}
+
+// HandleServerMigrationSuccessfully sets up the test server to respond to
+// GetServerMigration and Abort requests.
+func HandleServerMigrationSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/8600d31b-d1a1-4632-b2ff-45c2be1a70ff/migrations/1234", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprint(w, ServerMigrationOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("Unexpected method: [%s]", r.Method)
+		}
+	})
+}
+
+// HandleForceCompleteSuccessfully sets up the test server to respond to a
+// ForceComplete request.
+func HandleForceCompleteSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/8600d31b-d1a1-4632-b2ff-45c2be1a70ff/migrations/1234/action", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, `{"force_complete": null}`)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
@@ -0,0 +1,108 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/migrations"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+const serverID = "8600d31b-d1a1-4632-b2ff-45c2be1a70ff"
+
+func computeClient(microversion string) *gophercloud.ServiceClient {
+	sc := client.ServiceClient()
+	sc.Type = "compute"
+	sc.Microversion = microversion
+	return sc
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListSuccessfully(t)
+
+	since := time.Date(2024, 1, 29, 1, 0, 0, 0, time.FixedZone("CET", 3600))
+	listOpts := migrations.ListOpts{
+		SourceCompute: "compute1",
+		MigrationType: migrations.MigrationTypeLiveMigration,
+		ChangesSince:  &since,
+	}
+
+	pages := 0
+	err := migrations.List(client.ServiceClient(), listOpts).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		pages++
+
+		actual, err := migrations.ExtractMigrations(page)
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []migrations.Migration{ExpectedMigration}, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, pages)
+}
+
+func TestListServerMigrations(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListServerMigrationsSuccessfully(t)
+
+	allPages, err := migrations.ListServerMigrations(computeClient("2.23"), serverID).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+
+	actual, err := migrations.ExtractServerMigrations(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []migrations.ServerMigration{ExpectedServerMigration}, actual)
+}
+
+func TestGetServerMigration(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerMigrationSuccessfully(t)
+
+	actual, err := migrations.GetServerMigration(context.TODO(), computeClient("2.23"), serverID, 1234).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedServerMigration, *actual)
+}
+
+func TestForceComplete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleForceCompleteSuccessfully(t)
+
+	err := migrations.ForceComplete(context.TODO(), computeClient("2.22"), serverID, 1234).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestAbort(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerMigrationSuccessfully(t)
+
+	err := migrations.Abort(context.TODO(), computeClient("2.24"), serverID, 1234).ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestMicroversionTooLow(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	_, err := migrations.ListServerMigrations(computeClient("2.22"), serverID).AllPages(context.TODO())
+	var mvErr gophercloud.ErrMicroversionNotSupported
+	if !errors.As(err, &mvErr) {
+		t.Fatalf("Expected ErrMicroversionNotSupported, got %v", err)
+	}
+	th.CheckEquals(t, "2.23", mvErr.Required)
+
+	err = migrations.Abort(context.TODO(), computeClient("2.23"), serverID, 1234).ExtractErr()
+	if !errors.As(err, &mvErr) {
+		t.Fatalf("Expected ErrMicroversionNotSupported, got %v", err)
+	}
+	th.CheckEquals(t, "2.24", mvErr.Required)
+}
@@ -0,0 +1,52 @@
+/*
+Package provisioning creates a server together with the resources it needs:
+ports, a boot volume, data volumes and a floating IP. Each step waits for its
+resources to become usable, and when a step fails, every resource created so
+far is deleted again.
+
+The boot volume and data volumes are not deleted along with the server, as
+they were not created by the Compute service.
+
+Example to Provision a Server
+
+	clients := provisioning.Clients{
+		Compute:      computeClient,
+		Network:      networkClient,
+		BlockStorage: blockStorageClient,
+	}
+
+	spec := provisioning.Spec{
+		Server: servers.CreateOpts{
+			Name:      "web-01",
+			FlavorRef: "flavor-uuid",
+			KeyName:   "deploy",
+		},
+		NICs: []provisioning.NIC{
+			{Port: &ports.CreateOpts{NetworkID: "private-network-uuid"}},
+		},
+		SecurityGroups: []string{"web-security-group-uuid"},
+		BootVolume: &volumes.CreateOpts{
+			Size:    20,
+			ImageID: "image-uuid",
+		},
+		Volumes: []volumes.CreateOpts{
+			{Size: 100, Name: "web-01-data"},
+		},
+		FloatingIP: &floatingips.CreateOpts{
+			FloatingNetworkID: "public-network-uuid",
+		},
+		ServerGroupID: "server-group-uuid",
+	}
+
+	result, err := provisioning.Provision(context.TODO(), clients, spec)
+	if err != nil {
+		var provErr provisioning.ErrProvisioningFailed
+		if errors.As(err, &provErr) && len(provErr.RollbackErrors) > 0 {
+			fmt.Printf("some resources need to be cleaned up manually: %v\n", provErr.RollbackErrors)
+		}
+		panic(err)
+	}
+
+	fmt.Printf("%s is reachable at %s\n", result.Server.Name, result.FloatingIP.FloatingIP)
+*/
+package provisioning
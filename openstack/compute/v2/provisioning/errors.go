@@ -0,0 +1,47 @@
+package provisioning
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrProvisioningFailed is returned by Provision when a step fails. The
+// resources created before the failure have been rolled back, except for the
+// ones listed in RollbackErrors.
+type ErrProvisioningFailed struct {
+	// Step is the step which failed, e.g. "create port".
+	Step string
+
+	// Err is the error of the failed step.
+	Err error
+
+	// RollbackErrors are the errors encountered while rolling back. The
+	// corresponding resources may have to be cleaned up manually.
+	RollbackErrors []error
+}
+
+func (e ErrProvisioningFailed) Error() string {
+	s := fmt.Sprintf("Unable to %s: %v", e.Step, e.Err)
+	if len(e.RollbackErrors) > 0 {
+		s += fmt.Sprintf("; rollback failed: %v", errors.Join(e.RollbackErrors...))
+	}
+	return s
+}
+
+func (e ErrProvisioningFailed) Unwrap() error {
+	return e.Err
+}
+
+// ErrServerFailed is returned when the server enters the ERROR status while
+// it is being built.
+type ErrServerFailed struct {
+	// ServerID is the ID of the server.
+	ServerID string
+
+	// Fault is the fault message reported by the Compute service.
+	Fault string
+}
+
+func (e ErrServerFailed) Error() string {
+	return fmt.Sprintf("Server %s went into ERROR status: %s", e.ServerID, e.Fault)
+}
@@ -0,0 +1,413 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/volumeattach"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+)
+
+// DefaultRollbackTimeout is the time allowed for rolling back when
+// Spec.RollbackTimeout is not set.
+const DefaultRollbackTimeout = 5 * time.Minute
+
+// Clients are the service clients used by Provision. Network is only required
+// when the Spec creates ports or a floating IP, and BlockStorage when it
+// creates volumes.
+type Clients struct {
+	Compute      *gophercloud.ServiceClient
+	Network      *gophercloud.ServiceClient
+	BlockStorage *gophercloud.ServiceClient
+}
+
+// NIC is a network interface of the server. Exactly one of Network and Port
+// must be set.
+type NIC struct {
+	// Network attaches an existing network or port.
+	Network *servers.Network
+
+	// Port creates a port which is attached to the server. Its
+	// SecurityGroups default to Spec.SecurityGroups.
+	Port *ports.CreateOpts
+}
+
+// Spec declares a server and the resources it is provisioned with.
+type Spec struct {
+	// Server are the options of the server. Its Networks, BlockDevice and
+	// SecurityGroups are set from the rest of the Spec.
+	Server servers.CreateOpts
+
+	// NICs are the network interfaces of the server, in order. The server
+	// is attached according to the Compute service defaults when empty.
+	NICs []NIC
+
+	// SecurityGroups are the IDs of the security groups of the server. They
+	// also apply to the ports created for NICs which have none.
+	SecurityGroups []string
+
+	// BootVolume creates a volume the server boots from, typically from an
+	// image with ImageID. Server.ImageRef is ignored when it is set.
+	BootVolume *volumes.CreateOpts
+
+	// Volumes creates data volumes which are attached to the server once it
+	// is active, in order.
+	Volumes []volumes.CreateOpts
+
+	// FloatingIP creates a floating IP associated with the first NIC of the
+	// server. PortID is filled in unless set.
+	FloatingIP *floatingips.CreateOpts
+
+	// ServerGroupID is the ID of the server group to place the server in.
+	ServerGroupID string
+
+	// RollbackTimeout is the time allowed for rolling back after a failure.
+	// It defaults to DefaultRollbackTimeout. Rolling back is not interrupted
+	// by the cancellation of the context passed to Provision.
+	RollbackTimeout time.Duration
+}
+
+// Result are the resources created by Provision.
+type Result struct {
+	Server     *servers.Server
+	Ports      []ports.Port
+	BootVolume *volumes.Volume
+	Volumes    []volumes.Volume
+	FloatingIP *floatingips.FloatingIP
+}
+
+// provisioner holds the state of a Provision call.
+type provisioner struct {
+	clients Clients
+	spec    Spec
+	result  Result
+
+	// undo are the rollback steps of the resources created so far, in
+	// creation order.
+	undo []func(context.Context) error
+}
+
+// Provision creates a server and its resources as declared by spec: ports,
+// the boot volume, the server itself, data volumes and a floating IP, in that
+// order, waiting for each to become usable. If any step fails, everything
+// created so far is deleted and an ErrProvisioningFailed is returned.
+func Provision(ctx context.Context, clients Clients, spec Spec) (*Result, error) {
+	for i, nic := range spec.NICs {
+		if (nic.Network == nil) == (nic.Port == nil) {
+			return nil, fmt.Errorf("exactly one of Network and Port must be set for NIC %d", i)
+		}
+	}
+
+	p := &provisioner{clients: clients, spec: spec}
+
+	steps := []struct {
+		name string
+		fn   func(context.Context) error
+	}{
+		{"create ports", p.createPorts},
+		{"create boot volume", p.createBootVolume},
+		{"create server", p.createServer},
+		{"attach volumes", p.attachVolumes},
+		{"create floating IP", p.createFloatingIP},
+	}
+
+	for _, step := range steps {
+		if err := step.fn(ctx); err != nil {
+			return nil, ErrProvisioningFailed{
+				Step:           step.name,
+				Err:            err,
+				RollbackErrors: p.rollback(ctx),
+			}
+		}
+	}
+
+	return &p.result, nil
+}
+
+// rollback runs the undo steps in reverse order, and returns their errors.
+func (p *provisioner) rollback(ctx context.Context) []error {
+	timeout := p.spec.RollbackTimeout
+	if timeout == 0 {
+		timeout = DefaultRollbackTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(p.undo) - 1; i >= 0; i-- {
+		if err := p.undo[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (p *provisioner) createPorts(ctx context.Context) error {
+	for _, nic := range p.spec.NICs {
+		if nic.Port == nil {
+			continue
+		}
+
+		opts := *nic.Port
+		if opts.SecurityGroups == nil && p.spec.SecurityGroups != nil {
+			opts.SecurityGroups = &p.spec.SecurityGroups
+		}
+
+		port, err := ports.Create(ctx, p.clients.Network, opts).Extract()
+		if err != nil {
+			return err
+		}
+		p.result.Ports = append(p.result.Ports, *port)
+		p.undo = append(p.undo, func(ctx context.Context) error {
+			err := ports.Delete(ctx, p.clients.Network, port.ID).ExtractErr()
+			if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+				return fmt.Errorf("unable to delete port %s: %w", port.ID, err)
+			}
+			return nil
+		})
+	}
+	return nil
+}
+
+// createVolume creates a volume, registers its deletion and waits for it to
+// become available.
+func (p *provisioner) createVolume(ctx context.Context, opts volumes.CreateOpts) (*volumes.Volume, error) {
+	volume, err := volumes.Create(ctx, p.clients.BlockStorage, opts, nil).Extract()
+	if err != nil {
+		return nil, err
+	}
+	p.undo = append(p.undo, func(ctx context.Context) error {
+		return p.deleteVolume(ctx, volume.ID)
+	})
+
+	return p.waitForVolume(ctx, volume.ID, "available")
+}
+
+// waitForVolume waits for a volume to reach the given status, and fails
+// early when it reaches an error status.
+func (p *provisioner) waitForVolume(ctx context.Context, id, status string) (*volumes.Volume, error) {
+	var volume *volumes.Volume
+	err := gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		current, err := volumes.Get(ctx, p.clients.BlockStorage, id).Extract()
+		if err != nil {
+			return false, err
+		}
+		if current.Status == status {
+			volume = current
+			return true, nil
+		}
+		if strings.HasPrefix(current.Status, "error") {
+			return false, fmt.Errorf("volume %s went into %s status", id, current.Status)
+		}
+		return false, nil
+	})
+	return volume, err
+}
+
+// deleteVolume deletes a volume once it has been detached.
+func (p *provisioner) deleteVolume(ctx context.Context, id string) error {
+	err := gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		volume, err := volumes.Get(ctx, p.clients.BlockStorage, id).Extract()
+		if err != nil {
+			return false, err
+		}
+		switch volume.Status {
+		case "in-use", "attaching", "detaching", "creating", "downloading", "reserved":
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == nil {
+		err = volumes.Delete(ctx, p.clients.BlockStorage, id, nil).ExtractErr()
+	}
+	if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		return fmt.Errorf("unable to delete volume %s: %w", id, err)
+	}
+	return nil
+}
+
+func (p *provisioner) createBootVolume(ctx context.Context) error {
+	if p.spec.BootVolume == nil {
+		return nil
+	}
+
+	volume, err := p.createVolume(ctx, *p.spec.BootVolume)
+	if err != nil {
+		return err
+	}
+	p.result.BootVolume = volume
+	return nil
+}
+
+func (p *provisioner) createServer(ctx context.Context) error {
+	opts := p.spec.Server
+	if len(p.spec.SecurityGroups) > 0 {
+		opts.SecurityGroups = p.spec.SecurityGroups
+	}
+
+	if len(p.spec.NICs) > 0 {
+		var networks []servers.Network
+		created := 0
+		for _, nic := range p.spec.NICs {
+			if nic.Network != nil {
+				networks = append(networks, *nic.Network)
+				continue
+			}
+			networks = append(networks, servers.Network{Port: p.result.Ports[created].ID})
+			created++
+		}
+		opts.Networks = networks
+	}
+
+	if p.result.BootVolume != nil {
+		opts.ImageRef = ""
+		opts.BlockDevice = append([]servers.BlockDevice{{
+			SourceType:      servers.SourceVolume,
+			DestinationType: servers.DestinationVolume,
+			UUID:            p.result.BootVolume.ID,
+			BootIndex:       0,
+		}}, opts.BlockDevice...)
+	}
+
+	var hints servers.SchedulerHintOptsBuilder
+	if p.spec.ServerGroupID != "" {
+		hints = servers.SchedulerHintOpts{Group: p.spec.ServerGroupID}
+	}
+
+	server, err := servers.Create(ctx, p.clients.Compute, opts, hints).Extract()
+	if err != nil {
+		return err
+	}
+	p.undo = append(p.undo, func(ctx context.Context) error {
+		return p.deleteServer(ctx, server.ID)
+	})
+
+	err = gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+		current, err := servers.Get(ctx, p.clients.Compute, server.ID).Extract()
+		if err != nil {
+			return false, err
+		}
+		switch current.Status {
+		case "ACTIVE":
+			p.result.Server = current
+			return true, nil
+		case "ERROR":
+			return false, ErrServerFailed{ServerID: server.ID, Fault: current.Fault.Message}
+		}
+		return false, nil
+	})
+	return err
+}
+
+// deleteServer deletes a server and waits for it to be gone, so that its
+// ports and volumes are released.
+func (p *provisioner) deleteServer(ctx context.Context, id string) error {
+	err := servers.Delete(ctx, p.clients.Compute, id).ExtractErr()
+	if err == nil {
+		err = gophercloud.WaitFor(ctx, func(ctx context.Context) (bool, error) {
+			_, err := servers.Get(ctx, p.clients.Compute, id).Extract()
+			return err != nil, err
+		})
+	}
+	if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+		return fmt.Errorf("unable to delete server %s: %w", id, err)
+	}
+	return nil
+}
+
+func (p *provisioner) attachVolumes(ctx context.Context) error {
+	for _, opts := range p.spec.Volumes {
+		volume, err := p.createVolume(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		serverID, volumeID := p.result.Server.ID, volume.ID
+		_, err = volumeattach.Create(ctx, p.clients.Compute, serverID, volumeattach.CreateOpts{
+			VolumeID: volumeID,
+		}).Extract()
+		if err != nil {
+			return err
+		}
+		p.undo = append(p.undo, func(ctx context.Context) error {
+			err := volumeattach.Delete(ctx, p.clients.Compute, serverID, volumeID).ExtractErr()
+			if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+				return fmt.Errorf("unable to detach volume %s: %w", volumeID, err)
+			}
+			return nil
+		})
+
+		volume, err = p.waitForVolume(ctx, volumeID, "in-use")
+		if err != nil {
+			return err
+		}
+		p.result.Volumes = append(p.result.Volumes, *volume)
+	}
+	return nil
+}
+
+func (p *provisioner) createFloatingIP(ctx context.Context) error {
+	if p.spec.FloatingIP == nil {
+		return nil
+	}
+
+	opts := *p.spec.FloatingIP
+	if opts.PortID == "" {
+		portID, err := p.firstPortID(ctx)
+		if err != nil {
+			return err
+		}
+		opts.PortID = portID
+	}
+
+	fip, err := floatingips.Create(ctx, p.clients.Network, opts).Extract()
+	if err != nil {
+		return err
+	}
+	p.undo = append(p.undo, func(ctx context.Context) error {
+		err := floatingips.Delete(ctx, p.clients.Network, fip.ID).ExtractErr()
+		if err != nil && !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			return fmt.Errorf("unable to delete floating IP %s: %w", fip.ID, err)
+		}
+		return nil
+	})
+	p.result.FloatingIP = fip
+	return nil
+}
+
+// firstPortID returns the ID of the port of the first NIC of the server.
+func (p *provisioner) firstPortID(ctx context.Context) (string, error) {
+	if len(p.spec.NICs) > 0 {
+		nic := p.spec.NICs[0]
+		if nic.Port != nil {
+			return p.result.Ports[0].ID, nil
+		}
+		if nic.Network.Port != "" {
+			return nic.Network.Port, nil
+		}
+	}
+
+	listOpts := ports.ListOpts{DeviceID: p.result.Server.ID}
+	if len(p.spec.NICs) > 0 {
+		listOpts.NetworkID = p.spec.NICs[0].Network.UUID
+	}
+	allPages, err := ports.List(p.clients.Network, listOpts).AllPages(ctx)
+	if err != nil {
+		return "", err
+	}
+	serverPorts, err := ports.ExtractPorts(allPages)
+	if err != nil {
+		return "", err
+	}
+	if len(serverPorts) == 0 {
+		return "", fmt.Errorf("no port found for server %s", p.result.Server.ID)
+	}
+	return serverPorts[0].ID, nil
+}
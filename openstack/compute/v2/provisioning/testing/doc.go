@@ -0,0 +1,2 @@
+// provisioning unit tests
+package testing
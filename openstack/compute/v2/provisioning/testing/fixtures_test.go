@@ -0,0 +1,145 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+// FakeCloud is a minimal, stateful Compute, Networking and Block Storage
+// service. Every mutating request is recorded in Calls.
+type FakeCloud struct {
+	mu sync.Mutex
+
+	// Calls are the mutating requests, as "METHOD /path".
+	Calls []string
+
+	// Bodies are the bodies of the create requests, by path.
+	Bodies map[string]map[string]any
+
+	// FailFloatingIP makes floating IP creation fail.
+	FailFloatingIP bool
+
+	// ServerError makes the server go into ERROR status.
+	ServerError bool
+
+	volumes       map[string]string
+	serverDeleted bool
+	volumeCount   int
+}
+
+// HandleFakeCloud registers the handlers of a FakeCloud on the test handler
+// mux.
+func HandleFakeCloud(t *testing.T) *FakeCloud {
+	f := &FakeCloud{
+		Bodies:  make(map[string]map[string]any),
+		volumes: make(map[string]string),
+	}
+
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.Method != "GET" {
+			f.Calls = append(f.Calls, r.Method+" "+r.URL.Path)
+		}
+		if r.Method == "POST" {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			f.Bodies[r.URL.Path] = body
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		f.handle(t, w, r)
+	})
+
+	return f
+}
+
+func (f *FakeCloud) handle(t *testing.T, w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/ports":
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"port": {"id": "port1", "network_id": "net1"}}`)
+
+	case r.Method == "DELETE" && r.URL.Path == "/ports/port1":
+		w.WriteHeader(http.StatusNoContent)
+
+	case r.Method == "POST" && r.URL.Path == "/volumes":
+		f.volumeCount++
+		id := fmt.Sprintf("vol%d", f.volumeCount)
+		f.volumes[id] = "available"
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{"volume": {"id": %q, "status": "creating"}}`, id)
+
+	case len(path) == 2 && path[0] == "volumes":
+		status, ok := f.volumes[path[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, `{"volume": {"id": %q, "status": %q}}`, path[1], status)
+		case "DELETE":
+			delete(f.volumes, path[1])
+			w.WriteHeader(http.StatusAccepted)
+		}
+
+	case r.Method == "POST" && r.URL.Path == "/servers":
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprint(w, `{"server": {"id": "srv1"}}`)
+
+	case r.URL.Path == "/servers/srv1":
+		if f.serverDeleted {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case "GET":
+			if f.ServerError {
+				fmt.Fprint(w, `{"server": {"id": "srv1", "status": "ERROR", "fault": {"code": 500, "message": "No valid host was found."}}}`)
+				return
+			}
+			fmt.Fprint(w, `{"server": {"id": "srv1", "name": "web", "status": "ACTIVE"}}`)
+		case "DELETE":
+			f.serverDeleted = true
+			for id := range f.volumes {
+				f.volumes[id] = "available"
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+	case r.Method == "POST" && r.URL.Path == "/servers/srv1/os-volume_attachments":
+		f.volumes["vol2"] = "in-use"
+		fmt.Fprint(w, `{"volumeAttachment": {"id": "vol2", "volumeId": "vol2", "serverId": "srv1", "device": "/dev/vdb"}}`)
+
+	case r.Method == "DELETE" && r.URL.Path == "/servers/srv1/os-volume_attachments/vol2":
+		f.volumes["vol2"] = "available"
+		w.WriteHeader(http.StatusAccepted)
+
+	case r.Method == "POST" && r.URL.Path == "/floatingips":
+		if f.FailFloatingIP {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"NeutronError": {"message": "No more IP addresses available"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"floatingip": {"id": "fip1", "port_id": "port1", "floating_ip_address": "203.0.113.10"}}`)
+
+	default:
+		t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,164 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/provisioning"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/v2/openstack/networking/v2/ports"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+func clients() provisioning.Clients {
+	return provisioning.Clients{
+		Compute:      client.ServiceClient(),
+		Network:      client.ServiceClient(),
+		BlockStorage: client.ServiceClient(),
+	}
+}
+
+func spec() provisioning.Spec {
+	return provisioning.Spec{
+		Server: servers.CreateOpts{
+			Name:      "web",
+			FlavorRef: "flavor1",
+			ImageRef:  "ignored",
+		},
+		NICs: []provisioning.NIC{
+			{Port: &ports.CreateOpts{NetworkID: "net1"}},
+			{Network: &servers.Network{UUID: "net2"}},
+		},
+		SecurityGroups: []string{"sg1"},
+		BootVolume:     &volumes.CreateOpts{Size: 10, ImageID: "image1"},
+		Volumes:        []volumes.CreateOpts{{Size: 100}},
+		FloatingIP:     &floatingips.CreateOpts{FloatingNetworkID: "public"},
+		ServerGroupID:  "3a9b5e3b-4f0e-4d8b-9a16-0c6e6f7d6a51",
+	}
+}
+
+func TestProvision(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	fake := HandleFakeCloud(t)
+
+	result, err := provisioning.Provision(context.TODO(), clients(), spec())
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, "srv1", result.Server.ID)
+	th.CheckEquals(t, "ACTIVE", result.Server.Status)
+	th.AssertEquals(t, 1, len(result.Ports))
+	th.CheckEquals(t, "port1", result.Ports[0].ID)
+	th.CheckEquals(t, "vol1", result.BootVolume.ID)
+	th.AssertEquals(t, 1, len(result.Volumes))
+	th.CheckEquals(t, "vol2", result.Volumes[0].ID)
+	th.CheckEquals(t, "in-use", result.Volumes[0].Status)
+	th.CheckEquals(t, "fip1", result.FloatingIP.ID)
+
+	th.CheckDeepEquals(t, []string{
+		"POST /ports",
+		"POST /volumes",
+		"POST /servers",
+		"POST /volumes",
+		"POST /servers/srv1/os-volume_attachments",
+		"POST /floatingips",
+	}, fake.Calls)
+
+	th.CheckDeepEquals(t, map[string]any{
+		"network_id":      "net1",
+		"security_groups": []any{"sg1"},
+	}, fake.Bodies["/ports"]["port"])
+
+	th.CheckDeepEquals(t, map[string]any{
+		"name":            "web",
+		"flavorRef":       "flavor1",
+		"imageRef":        "",
+		"security_groups": []any{map[string]any{"name": "sg1"}},
+		"networks": []any{
+			map[string]any{"port": "port1"},
+			map[string]any{"uuid": "net2"},
+		},
+		"block_device_mapping_v2": []any{
+			map[string]any{
+				"source_type":           "volume",
+				"destination_type":      "volume",
+				"uuid":                  "vol1",
+				"boot_index":            float64(0),
+				"delete_on_termination": false,
+			},
+		},
+	}, fake.Bodies["/servers"]["server"])
+	th.CheckDeepEquals(t, map[string]any{"group": "3a9b5e3b-4f0e-4d8b-9a16-0c6e6f7d6a51"}, fake.Bodies["/servers"]["os:scheduler_hints"])
+
+	th.CheckDeepEquals(t, map[string]any{
+		"floating_network_id": "public",
+		"port_id":             "port1",
+	}, fake.Bodies["/floatingips"]["floatingip"])
+}
+
+func TestProvisionRollback(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	fake := HandleFakeCloud(t)
+	fake.FailFloatingIP = true
+
+	_, err := provisioning.Provision(context.TODO(), clients(), spec())
+
+	var provErr provisioning.ErrProvisioningFailed
+	if !errors.As(err, &provErr) {
+		t.Fatalf("Expected ErrProvisioningFailed, got %v", err)
+	}
+	th.CheckEquals(t, "create floating IP", provErr.Step)
+	th.CheckEquals(t, 0, len(provErr.RollbackErrors))
+
+	th.CheckDeepEquals(t, []string{
+		"POST /ports",
+		"POST /volumes",
+		"POST /servers",
+		"POST /volumes",
+		"POST /servers/srv1/os-volume_attachments",
+		"POST /floatingips",
+		"DELETE /servers/srv1/os-volume_attachments/vol2",
+		"DELETE /volumes/vol2",
+		"DELETE /servers/srv1",
+		"DELETE /volumes/vol1",
+		"DELETE /ports/port1",
+	}, fake.Calls)
+}
+
+func TestProvisionServerError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	fake := HandleFakeCloud(t)
+	fake.ServerError = true
+
+	s := spec()
+	s.BootVolume = nil
+	s.Volumes = nil
+	_, err := provisioning.Provision(context.TODO(), clients(), s)
+
+	var serverErr provisioning.ErrServerFailed
+	if !errors.As(err, &serverErr) {
+		t.Fatalf("Expected ErrServerFailed, got %v", err)
+	}
+	th.CheckEquals(t, "No valid host was found.", serverErr.Fault)
+
+	th.CheckDeepEquals(t, []string{
+		"POST /ports",
+		"POST /servers",
+		"DELETE /servers/srv1",
+		"DELETE /ports/port1",
+	}, fake.Calls)
+}
+
+func TestProvisionInvalidNIC(t *testing.T) {
+	s := spec()
+	s.NICs = []provisioning.NIC{{}}
+
+	_, err := provisioning.Provision(context.TODO(), clients(), s)
+	th.CheckEquals(t, "exactly one of Network and Port must be set for NIC 0", err.Error())
+}
@@ -21,5 +21,35 @@ Example of Creating a new RemoteConsole
 	}
 
 	fmt.Printf("Console URL: %s\n", remtoteConsole.URL)
+
+Example of Interacting with a Serial Console
+
+	createOpts := remoteconsoles.CreateOpts{
+	  Protocol: remoteconsoles.ConsoleProtocolSerial,
+	  Type:     remoteconsoles.ConsoleTypeSerial,
+	}
+
+	remoteConsole, err := remoteconsoles.Create(context.TODO(), computeClient, serverID, createOpts).Extract()
+	if err != nil {
+	  panic(err)
+	}
+
+	conn, err := remoteconsoles.Dial(context.TODO(), remoteConsole.URL, nil)
+	if err != nil {
+	  panic(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("\r\n")); err != nil {
+	  panic(err)
+	}
+
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	output, err := io.ReadAll(conn)
+	if err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+	  panic(err)
+	}
+
+	fmt.Printf("Console output: %s\n", output)
 */
 package remoteconsoles
@@ -0,0 +1,265 @@
+package testing
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/remoteconsoles"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+// fakeConsoleProxy stands in for nova-serialproxy. It accepts a websocket
+// handshake and hands the connection to serve.
+func fakeConsoleProxy(t *testing.T, path string, serve func(rw *bufio.ReadWriter)) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		th.AssertEquals(t, path, r.URL.Path)
+		th.AssertEquals(t, "token=3c5e4b2a", r.URL.RawQuery)
+		th.TestHeader(t, r, "Upgrade", "websocket")
+		th.TestHeader(t, r, "Connection", "Upgrade")
+		th.TestHeader(t, r, "Sec-WebSocket-Version", "13")
+		th.TestHeader(t, r, "Sec-WebSocket-Protocol", "binary")
+		th.TestHeader(t, r, "Origin", "http://"+r.Host)
+
+		h := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"))
+
+		conn, rw, err := http.NewResponseController(w).Hijack()
+		th.AssertNoErr(t, err)
+		defer conn.Close()
+
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+		rw.WriteString("Upgrade: websocket\r\n")
+		rw.WriteString("Connection: Upgrade\r\n")
+		rw.WriteString("Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(h[:]) + "\r\n")
+		rw.WriteString("Sec-WebSocket-Protocol: binary\r\n\r\n")
+		th.AssertNoErr(t, rw.Flush())
+
+		serve(rw)
+	}))
+}
+
+// readClientFrame reads a frame sent by the client, which must be masked.
+func readClientFrame(t *testing.T, r io.Reader) (byte, []byte) {
+	t.Helper()
+
+	var header [2]byte
+	_, err := io.ReadFull(r, header[:])
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, header[1]&0x80 != 0)
+
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		_, err = io.ReadFull(r, ext[:])
+		th.AssertNoErr(t, err)
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, err = io.ReadFull(r, ext[:])
+		th.AssertNoErr(t, err)
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	var mask [4]byte
+	_, err = io.ReadFull(r, mask[:])
+	th.AssertNoErr(t, err)
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(r, payload)
+	th.AssertNoErr(t, err)
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return header[0] & 0x0f, payload
+}
+
+// writeServerFrame writes an unmasked frame, as sent by the proxy.
+func writeServerFrame(t *testing.T, rw *bufio.ReadWriter, fin bool, opcode byte, payload []byte) {
+	t.Helper()
+
+	b0 := opcode
+	if fin {
+		b0 |= 0x80
+	}
+	rw.WriteByte(b0)
+	if len(payload) < 126 {
+		rw.WriteByte(byte(len(payload)))
+	} else {
+		rw.WriteByte(126)
+		binary.Write(rw, binary.BigEndian, uint16(len(payload)))
+	}
+	rw.Write(payload)
+	th.AssertNoErr(t, rw.Flush())
+}
+
+func TestWebsocketURL(t *testing.T) {
+	for _, tc := range []struct {
+		consoleURL string
+		expected   string
+	}{
+		{
+			consoleURL: "ws://127.0.0.1:6083/?token=3c5e4b2a",
+			expected:   "ws://127.0.0.1:6083/?token=3c5e4b2a",
+		},
+		{
+			consoleURL: "https://127.0.0.1:6080/vnc_auto.html?path=%3Ftoken%3D3c5e4b2a",
+			expected:   "wss://127.0.0.1:6080/?token=3c5e4b2a",
+		},
+		{
+			consoleURL: "http://127.0.0.1:6080/vnc_lite.html?path=websockify%3Ftoken%3D3c5e4b2a",
+			expected:   "ws://127.0.0.1:6080/websockify?token=3c5e4b2a",
+		},
+		{
+			consoleURL: "http://127.0.0.1:6090/mks/vnc_auto.html?token=3c5e4b2a",
+			expected:   "ws://127.0.0.1:6090/mks/?token=3c5e4b2a",
+		},
+	} {
+		actual, err := remoteconsoles.WebsocketURL(tc.consoleURL)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, tc.expected, actual)
+	}
+
+	_, err := remoteconsoles.WebsocketURL("spice://127.0.0.1:6082/")
+	th.AssertErr(t, err)
+}
+
+func TestDialSerialConsole(t *testing.T) {
+	done := make(chan struct{})
+	server := fakeConsoleProxy(t, "/", func(rw *bufio.ReadWriter) {
+		defer close(done)
+
+		writeServerFrame(t, rw, true, 0x2, []byte("login: "))
+
+		opcode, payload := readClientFrame(t, rw)
+		th.AssertEquals(t, byte(0x2), opcode)
+		th.AssertEquals(t, "root\n", string(payload))
+
+		// A ping in the middle of a fragmented message.
+		writeServerFrame(t, rw, false, 0x2, []byte("Pass"))
+		writeServerFrame(t, rw, true, 0x9, []byte("ping"))
+		writeServerFrame(t, rw, true, 0x0, []byte("word: "))
+
+		opcode, payload = readClientFrame(t, rw)
+		th.AssertEquals(t, byte(0xa), opcode)
+		th.AssertEquals(t, "ping", string(payload))
+
+		long := strings.Repeat("x", 300)
+		writeServerFrame(t, rw, true, 0x2, []byte(long))
+
+		opcode, payload = readClientFrame(t, rw)
+		th.AssertEquals(t, byte(0x8), opcode)
+		th.AssertEquals(t, uint16(1000), binary.BigEndian.Uint16(payload))
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	consoleURL := strings.Replace(server.URL, "http://", "ws://", 1) + "/?token=3c5e4b2a"
+	conn, err := remoteconsoles.Dial(ctx, consoleURL, nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "binary", conn.Subprotocol())
+	th.AssertNoErr(t, conn.SetDeadline(time.Now().Add(10*time.Second)))
+
+	var rwc io.ReadWriteCloser = conn
+
+	buf := make([]byte, 64)
+	n, err := rwc.Read(buf)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "login: ", string(buf[:n]))
+
+	_, err = rwc.Write([]byte("root\n"))
+	th.AssertNoErr(t, err)
+
+	n, err = io.ReadFull(rwc, buf[:10])
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "Password: ", string(buf[:n]))
+
+	long := make([]byte, 300)
+	_, err = io.ReadFull(rwc, long)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, strings.Repeat("x", 300), string(long))
+
+	th.AssertNoErr(t, rwc.Close())
+	<-done
+}
+
+func TestDialNoVNCConsole(t *testing.T) {
+	server := fakeConsoleProxy(t, "/websockify", func(rw *bufio.ReadWriter) {
+		writeServerFrame(t, rw, true, 0x2, []byte("RFB 003.008\n"))
+		writeServerFrame(t, rw, true, 0x8, []byte{0x03, 0xe8})
+
+		opcode, _ := readClientFrame(t, rw)
+		th.AssertEquals(t, byte(0x8), opcode)
+	})
+	defer server.Close()
+
+	consoleURL := server.URL + "/vnc_lite.html?path=websockify%3Ftoken%3D3c5e4b2a"
+	conn, err := remoteconsoles.Dial(context.TODO(), consoleURL, nil)
+	th.AssertNoErr(t, err)
+	defer conn.Close()
+
+	data, err := io.ReadAll(conn)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "RFB 003.008\n", string(data))
+}
+
+func TestDialHandshakeFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := remoteconsoles.Dial(context.TODO(), server.URL+"/?token=expired", nil)
+
+	var handshakeErr remoteconsoles.ErrHandshakeFailed
+	th.AssertEquals(t, true, errors.As(err, &handshakeErr))
+	th.AssertEquals(t, http.StatusForbidden, handshakeErr.StatusCode)
+}
+
+func TestDialCancelledDuringHandshake(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	th.AssertNoErr(t, err)
+	defer listener.Close()
+
+	// The proxy reads the handshake request but never answers it.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, err := http.ReadRequest(bufio.NewReader(conn)); err == nil {
+			cancel()
+		}
+		io.Copy(io.Discard, conn)
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := remoteconsoles.Dial(ctx, "http://"+listener.Addr().String()+"/?token=3c5e4b2a", nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		th.AssertEquals(t, context.Canceled, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Dial did not return after its context was cancelled")
+	}
+}
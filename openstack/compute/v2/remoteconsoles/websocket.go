@@ -0,0 +1,426 @@
+package remoteconsoles
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID is the GUID used to compute Sec-WebSocket-Accept, as defined
+// by RFC 6455.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxFrameSize is the largest websocket frame Conn accepts.
+const maxFrameSize = 16 << 20
+
+// Websocket opcodes, as defined by RFC 6455.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xa
+)
+
+// DialOpts specifies optional parameters to Dial.
+type DialOpts struct {
+	// TLSConfig is used for wss:// and https:// console URLs.
+	TLSConfig *tls.Config
+
+	// Header contains additional headers to send with the handshake.
+	Header http.Header
+
+	// Subprotocols are the websocket subprotocols to request. It defaults to
+	// "binary", which the Compute console proxies support.
+	Subprotocols []string
+
+	// Origin is the Origin header of the handshake, which the console
+	// proxies check against the console host. It defaults to the scheme and
+	// host of the console URL.
+	Origin string
+}
+
+// ErrHandshakeFailed is returned by Dial when the console proxy rejects the
+// websocket handshake, e.g. because the console token has expired.
+type ErrHandshakeFailed struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Reason describes what was wrong with the response.
+	Reason string
+}
+
+func (e ErrHandshakeFailed) Error() string {
+	return fmt.Sprintf("Websocket handshake failed with status %d: %s", e.StatusCode, e.Reason)
+}
+
+// Conn is a websocket connection to a remote console. It implements
+// io.ReadWriteCloser: Read returns the data sent by the console and Write
+// sends data to it, each Write as a single binary message.
+type Conn struct {
+	conn        net.Conn
+	br          *bufio.Reader
+	subprotocol string
+
+	// readBuf holds the unread part of the current message.
+	readBuf []byte
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// WebsocketURL returns the websocket URL of a remote console URL, as returned
+// by Create. Serial and MKS console URLs are websocket URLs already, while
+// noVNC console URLs point to an HTML page which takes the websocket path in
+// its "path" query parameter.
+func WebsocketURL(consoleURL string) (string, error) {
+	u, err := url.Parse(consoleURL)
+	if err != nil {
+		return "", err
+	}
+
+	switch u.Scheme {
+	case "ws", "wss":
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("unsupported console URL scheme %q", u.Scheme)
+	}
+
+	if path := u.Query().Get("path"); path != "" {
+		ref, err := url.Parse(path)
+		if err != nil {
+			return "", err
+		}
+		u.Path = "/" + strings.TrimPrefix(ref.Path, "/")
+		u.RawQuery = ref.RawQuery
+	} else if strings.HasSuffix(u.Path, ".html") {
+		u.Path = u.Path[:strings.LastIndex(u.Path, "/")+1]
+	}
+
+	return u.String(), nil
+}
+
+// Dial connects to a remote console, given the URL returned by Create.
+func Dial(ctx context.Context, consoleURL string, opts *DialOpts) (*Conn, error) {
+	if opts == nil {
+		opts = &DialOpts{}
+	}
+
+	wsURL, err := WebsocketURL(consoleURL)
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(wsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "wss" {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	// The handshake is not bound to ctx: interrupt it by expiring the
+	// deadline of the connection if ctx is done meanwhile.
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Unix(1, 0))
+	})
+
+	c, err := dialHandshake(ctx, conn, u, opts)
+	if !stop() {
+		conn.Close()
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// dialHandshake performs the TLS handshake if needed, followed by the
+// websocket opening handshake.
+func dialHandshake(ctx context.Context, conn net.Conn, u *url.URL, opts *DialOpts) (*Conn, error) {
+	if u.Scheme == "wss" {
+		config := opts.TLSConfig
+		if config == nil {
+			config = &tls.Config{}
+		} else {
+			config = config.Clone()
+		}
+		if config.ServerName == "" {
+			config.ServerName = u.Hostname()
+		}
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	return handshake(ctx, conn, u, opts)
+}
+
+// handshake performs the websocket opening handshake on conn.
+func handshake(ctx context.Context, conn net.Conn, u *url.URL, opts *DialOpts) (*Conn, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	origin := opts.Origin
+	if origin == "" {
+		scheme := "http"
+		if u.Scheme == "wss" {
+			scheme = "https"
+		}
+		origin = scheme + "://" + u.Host
+	}
+
+	subprotocols := opts.Subprotocols
+	if len(subprotocols) == 0 {
+		subprotocols = []string{"binary"}
+	}
+
+	httpURL := *u
+	httpURL.Scheme = strings.Replace(u.Scheme, "ws", "http", 1)
+	req, err := http.NewRequestWithContext(ctx, "GET", httpURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range opts.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Protocol", strings.Join(subprotocols, ", "))
+	req.Header.Set("Origin", origin)
+
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, ErrHandshakeFailed{StatusCode: resp.StatusCode, Reason: "unexpected status"}
+	}
+	if !strings.EqualFold(resp.Header.Get("Upgrade"), "websocket") {
+		return nil, ErrHandshakeFailed{StatusCode: resp.StatusCode, Reason: "missing Upgrade header"}
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != acceptKey(key) {
+		return nil, ErrHandshakeFailed{StatusCode: resp.StatusCode, Reason: "invalid Sec-WebSocket-Accept header"}
+	}
+
+	return &Conn{
+		conn:        conn,
+		br:          br,
+		subprotocol: resp.Header.Get("Sec-WebSocket-Protocol"),
+	}, nil
+}
+
+// acceptKey computes the Sec-WebSocket-Accept value for a key.
+func acceptKey(key string) string {
+	h := sha1.Sum([]byte(key + websocketGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// Subprotocol returns the subprotocol selected by the console proxy.
+func (c *Conn) Subprotocol() string {
+	return c.subprotocol
+}
+
+// SetDeadline sets the read and write deadlines of the underlying
+// connection, which is useful to wait for console output with a timeout.
+func (c *Conn) SetDeadline(t time.Time) error {
+	return c.conn.SetDeadline(t)
+}
+
+// Read reads data sent by the console. It returns io.EOF once the console
+// proxy closes the connection.
+func (c *Conn) Read(p []byte) (int, error) {
+	for len(c.readBuf) == 0 {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, err
+		}
+
+		switch opcode {
+		case opText, opBinary, opContinuation:
+			c.readBuf = payload
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return 0, err
+			}
+		case opPong:
+		case opClose:
+			c.writeMu.Lock()
+			// Echo the close frame, as required by RFC 6455.
+			_ = c.writeFrameLocked(opClose, payload)
+			c.writeMu.Unlock()
+			return 0, io.EOF
+		default:
+			return 0, fmt.Errorf("unexpected websocket opcode %#x", opcode)
+		}
+	}
+
+	n := copy(p, c.readBuf)
+	c.readBuf = c.readBuf[n:]
+	return n, nil
+}
+
+// readFrame reads a single websocket frame.
+func (c *Conn) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.br, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxFrameSize {
+		return 0, nil, fmt.Errorf("websocket frame of %d bytes exceeds the limit of %d bytes", length, maxFrameSize)
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return opcode, payload, nil
+}
+
+// Write sends data to the console as a single binary message.
+func (c *Conn) Write(p []byte) (int, error) {
+	if err := c.writeFrame(opBinary, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.writeFrameLocked(opcode, payload)
+}
+
+// writeFrameLocked writes a single masked frame, as required from clients.
+// The caller must hold writeMu.
+func (c *Conn) writeFrameLocked(opcode byte, payload []byte) error {
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, 0x80|opcode)
+
+	switch length := len(payload); {
+	case length < 126:
+		frame = append(frame, 0x80|byte(length))
+	case length <= 0xffff:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(length))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(length))
+	}
+
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// Close sends a close frame to the console proxy and closes the connection.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		// Status code 1000, normal closure.
+		writeErr := c.writeFrame(opClose, []byte{0x03, 0xe8})
+		closeErr := c.conn.Close()
+		if writeErr != nil && !errors.Is(writeErr, net.ErrClosed) {
+			c.closeErr = writeErr
+		} else {
+			c.closeErr = closeErr
+		}
+	})
+	return c.closeErr
+}
@@ -45,6 +45,20 @@ Example to List Detail Servers
 		fmt.Printf("%+v\n", server)
 	}
 
+Example to Iterate over Servers
+
+	listOpts := servers.ListOpts{
+		AllTenants: true,
+	}
+
+	for server, err := range servers.ListIter(context.TODO(), computeClient, listOpts) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("%+v\n", server)
+	}
+
 Example to Create a Server
 
 	createOpts := servers.CreateOpts{
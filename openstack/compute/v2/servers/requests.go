@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"maps"
 	"net"
 	"regexp"
@@ -129,6 +130,12 @@ func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pa
 	})
 }
 
+// ListIter returns an iterator over the servers returned by List. Pages are
+// fetched lazily, so breaking out of the loop stops further requests.
+func ListIter(ctx context.Context, client *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[Server, error] {
+	return pagination.Items(ctx, List(client, opts), ExtractServers)
+}
+
 // SchedulerHintOptsBuilder builds the scheduler hints into a serializable format.
 type SchedulerHintOptsBuilder interface {
 	ToSchedulerHintsMap() (map[string]any, error)
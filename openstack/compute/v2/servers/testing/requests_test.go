@@ -45,6 +45,23 @@ func TestListServers(t *testing.T) {
 	}
 }
 
+func TestListServersIter(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleServerListSuccessfully(t)
+
+	var actual []servers.Server
+	for server, err := range servers.ListIter(context.TODO(), client.ServiceClient(), servers.ListOpts{}) {
+		th.AssertNoErr(t, err)
+		actual = append(actual, server)
+	}
+
+	th.AssertEquals(t, 3, len(actual))
+	th.CheckDeepEquals(t, ServerHerp, actual[0])
+	th.CheckDeepEquals(t, ServerDerp, actual[1])
+	th.CheckDeepEquals(t, ServerMerp, actual[2])
+}
+
 func TestListAllServers(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -0,0 +1,40 @@
+/*
+Package servershares attaches Manila shares to servers through the Compute
+API, so that they can be mounted in the guest with virtiofs.
+You need to specify at least "2.97" microversion for the ComputeClient to use
+that API, or enable microversion negotiation on the provider. Shares can only
+be attached to and detached from stopped servers.
+
+Example to Attach a Share to a Server
+
+	computeClient.Microversion = "2.97"
+
+	createOpts := servershares.CreateOpts{
+		ShareID: "e8debdc0-447a-4376-a10a-4cd9122d7986",
+		Tag:     "data",
+	}
+
+	share, err := servershares.Create(context.TODO(), computeClient, serverID, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Shares of a Server
+
+	shares, err := servershares.List(context.TODO(), computeClient, serverID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, share := range shares {
+		fmt.Printf("%s: %s\n", share.Tag, share.Status)
+	}
+
+Example to Detach a Share from a Server
+
+	err := servershares.Delete(context.TODO(), computeClient, serverID, shareID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package servershares
@@ -0,0 +1,80 @@
+package servershares
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// minMicroversion is the lowest microversion exposing server shares.
+const minMicroversion = "2.97"
+
+// List lists the shares attached to a server. The API is not paginated.
+// This requires microversion 2.97 or later.
+func List(ctx context.Context, client *gophercloud.ServiceClient, serverID string) (r ListResult) {
+	resp, err := client.Get(ctx, listURL(client, serverID), &r.Body, &gophercloud.RequestOpts{
+		OkCodes:         []int{200},
+		MinMicroversion: minMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the
+// Create request.
+type CreateOptsBuilder interface {
+	ToServerShareCreateMap() (map[string]any, error)
+}
+
+// CreateOpts specifies parameters of a new share attachment.
+type CreateOpts struct {
+	// ShareID is the ID of the Manila share to attach.
+	ShareID string `json:"share_id" required:"true"`
+
+	// Tag is the device tag the share is exposed with in the guest. It
+	// defaults to the share ID.
+	Tag string `json:"tag,omitempty"`
+}
+
+// ToServerShareCreateMap constructs a request body from CreateOpts.
+func (opts CreateOpts) ToServerShareCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "share")
+}
+
+// Create attaches a share to a server. The server must be stopped.
+// This requires microversion 2.97 or later.
+func Create(ctx context.Context, client *gophercloud.ServiceClient, serverID string, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToServerShareCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(ctx, createURL(client, serverID), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes:         []int{201},
+		MinMicroversion: minMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Get retrieves a share attached to a server.
+// This requires microversion 2.97 or later.
+func Get(ctx context.Context, client *gophercloud.ServiceClient, serverID, shareID string) (r GetResult) {
+	resp, err := client.Get(ctx, getURL(client, serverID, shareID), &r.Body, &gophercloud.RequestOpts{
+		OkCodes:         []int{200},
+		MinMicroversion: minMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete detaches a share from a server. The server must be stopped.
+// This requires microversion 2.97 or later.
+func Delete(ctx context.Context, client *gophercloud.ServiceClient, serverID, shareID string) (r DeleteResult) {
+	resp, err := client.Delete(ctx, deleteURL(client, serverID, shareID), &gophercloud.RequestOpts{
+		OkCodes:         []int{200, 204},
+		MinMicroversion: minMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
@@ -0,0 +1,81 @@
+package servershares
+
+import "github.com/gophercloud/gophercloud/v2"
+
+// ShareStatus is the status of a share attachment.
+type ShareStatus string
+
+const (
+	ShareStatusAttaching ShareStatus = "attaching"
+	ShareStatusInactive  ShareStatus = "inactive"
+	ShareStatusActive    ShareStatus = "active"
+	ShareStatusDetaching ShareStatus = "detaching"
+	ShareStatusError     ShareStatus = "error"
+)
+
+// Share is a Manila share attached to a server.
+type Share struct {
+	// ShareID is the ID of the Manila share.
+	ShareID string `json:"share_id"`
+
+	// Status is the status of the attachment. It is inactive while the
+	// server is stopped and active while it is running.
+	Status ShareStatus `json:"status"`
+
+	// Tag is the device tag the share is exposed with in the guest.
+	Tag string `json:"tag"`
+
+	// UUID is the ID of the attachment. It is only visible to
+	// administrators.
+	UUID string `json:"uuid"`
+
+	// ExportLocation is the export location of the share. It is only
+	// visible to administrators.
+	ExportLocation string `json:"export_location"`
+}
+
+type shareResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any shareResult as a Share.
+func (r shareResult) Extract() (*Share, error) {
+	var s struct {
+		Share *Share `json:"share"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Share, err
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a Share.
+type CreateResult struct {
+	shareResult
+}
+
+// GetResult is the response from a Get operation. Call its Extract method to
+// interpret it as a Share.
+type GetResult struct {
+	shareResult
+}
+
+// DeleteResult is the response from a Delete operation. Call its ExtractErr
+// method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// ListResult is the response from a List operation. Call its Extract method
+// to interpret it as a slice of Shares.
+type ListResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a ListResult as a slice of Shares.
+func (r ListResult) Extract() ([]Share, error) {
+	var s struct {
+		Shares []Share `json:"shares"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Shares, err
+}
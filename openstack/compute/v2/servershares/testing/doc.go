@@ -0,0 +1,2 @@
+// servershares unit tests
+package testing
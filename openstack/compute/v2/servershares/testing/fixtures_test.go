@@ -0,0 +1,124 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servershares"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+const serverID = "b16ba811-199d-4ffd-8839-ba96c1185a67"
+
+// ListOutput is a sample response to a List request.
+const ListOutput = `
+{
+    "shares": [
+        {
+            "share_id": "e8debdc0-447a-4376-a10a-4cd9122d7986",
+            "status": "inactive",
+            "tag": "data"
+        }
+    ]
+}
+`
+
+// CreateRequest is a sample request to attach a share.
+const CreateRequest = `
+{
+    "share": {
+        "share_id": "e8debdc0-447a-4376-a10a-4cd9122d7986",
+        "tag": "data"
+    }
+}
+`
+
+// GetOutput is a sample response to a Get request, as seen by an
+// administrator.
+const GetOutput = `
+{
+    "share": {
+        "share_id": "e8debdc0-447a-4376-a10a-4cd9122d7986",
+        "status": "inactive",
+        "tag": "data",
+        "uuid": "68ba1762-fd6d-4221-8311-f3193dd93404",
+        "export_location": "10.0.0.50:/mnt/foo"
+    }
+}
+`
+
+// CreateOutput is a sample response to a Create request.
+const CreateOutput = `
+{
+    "share": {
+        "share_id": "e8debdc0-447a-4376-a10a-4cd9122d7986",
+        "status": "inactive",
+        "tag": "data"
+    }
+}
+`
+
+// ExpectedShare is the share in ListOutput and CreateOutput.
+var ExpectedShare = servershares.Share{
+	ShareID: "e8debdc0-447a-4376-a10a-4cd9122d7986",
+	Status:  servershares.ShareStatusInactive,
+	Tag:     "data",
+}
+
+// ExpectedShareDetails is the share in GetOutput.
+var ExpectedShareDetails = servershares.Share{
+	ShareID:        "e8debdc0-447a-4376-a10a-4cd9122d7986",
+	Status:         servershares.ShareStatusInactive,
+	Tag:            "data",
+	UUID:           "68ba1762-fd6d-4221-8311-f3193dd93404",
+	ExportLocation: "10.0.0.50:/mnt/foo",
+}
+
+// HandleListSuccessfully configures the test server to respond to a List
+// request.
+func HandleListSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/"+serverID+"/shares", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Nova-API-Version", "2.97")
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, ListOutput)
+	})
+}
+
+// HandleCreateSuccessfully configures the test server to respond to a Create
+// request.
+func HandleCreateSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/"+serverID+"/shares", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Nova-API-Version", "2.97")
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, CreateOutput)
+	})
+}
+
+// HandleShareSuccessfully configures the test server to respond to Get and
+// Delete requests.
+func HandleShareSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/"+serverID+"/shares/e8debdc0-447a-4376-a10a-4cd9122d7986", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Nova-API-Version", "2.97")
+
+		switch r.Method {
+		case "GET":
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprint(w, GetOutput)
+		case "DELETE":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("Unexpected method: [%s]", r.Method)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servershares"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+func computeClient(microversion string) *gophercloud.ServiceClient {
+	sc := client.ServiceClient()
+	sc.Type = "compute"
+	sc.Microversion = microversion
+	return sc
+}
+
+func TestList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListSuccessfully(t)
+
+	actual, err := servershares.List(context.TODO(), computeClient("2.97"), serverID).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []servershares.Share{ExpectedShare}, actual)
+}
+
+func TestCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateSuccessfully(t)
+
+	createOpts := servershares.CreateOpts{
+		ShareID: "e8debdc0-447a-4376-a10a-4cd9122d7986",
+		Tag:     "data",
+	}
+
+	actual, err := servershares.Create(context.TODO(), computeClient("2.97"), serverID, createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedShare, *actual)
+}
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleShareSuccessfully(t)
+
+	actual, err := servershares.Get(context.TODO(), computeClient("2.97"), serverID, "e8debdc0-447a-4376-a10a-4cd9122d7986").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedShareDetails, *actual)
+}
+
+func TestDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleShareSuccessfully(t)
+
+	err := servershares.Delete(context.TODO(), computeClient("2.97"), serverID, "e8debdc0-447a-4376-a10a-4cd9122d7986").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestMicroversionTooLow(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	_, err := servershares.List(context.TODO(), computeClient("2.96"), serverID).Extract()
+	var mvErr gophercloud.ErrMicroversionNotSupported
+	if !errors.As(err, &mvErr) {
+		t.Fatalf("Expected ErrMicroversionNotSupported, got %v", err)
+	}
+	th.CheckEquals(t, "2.97", mvErr.Required)
+}
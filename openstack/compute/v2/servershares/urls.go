@@ -0,0 +1,19 @@
+package servershares
+
+import "github.com/gophercloud/gophercloud/v2"
+
+func listURL(client *gophercloud.ServiceClient, serverID string) string {
+	return client.ServiceURL("servers", serverID, "shares")
+}
+
+func createURL(client *gophercloud.ServiceClient, serverID string) string {
+	return listURL(client, serverID)
+}
+
+func getURL(client *gophercloud.ServiceClient, serverID, shareID string) string {
+	return client.ServiceURL("servers", serverID, "shares", shareID)
+}
+
+func deleteURL(client *gophercloud.ServiceClient, serverID, shareID string) string {
+	return getURL(client, serverID, shareID)
+}
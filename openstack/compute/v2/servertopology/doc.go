@@ -0,0 +1,20 @@
+/*
+Package servertopology retrieves the NUMA topology of a server, including its
+CPU pinning, through the Compute API.
+You need to specify at least "2.78" microversion for the ComputeClient to use
+that API, or enable microversion negotiation on the provider.
+
+Example to Get the Topology of a Server
+
+	computeClient.Microversion = "2.78"
+
+	topology, err := servertopology.Get(context.TODO(), computeClient, serverID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, node := range topology.Nodes {
+		fmt.Printf("vCPUs %v pinned to %v\n", node.VCPUSet, node.CPUPinning)
+	}
+*/
+package servertopology
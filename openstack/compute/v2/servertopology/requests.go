@@ -0,0 +1,21 @@
+package servertopology
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// minMicroversion is the lowest microversion exposing the server topology.
+const minMicroversion = "2.78"
+
+// Get retrieves the NUMA topology of a server.
+// This requires microversion 2.78 or later.
+func Get(ctx context.Context, client *gophercloud.ServiceClient, serverID string) (r GetResult) {
+	resp, err := client.Get(ctx, getURL(client, serverID), &r.Body, &gophercloud.RequestOpts{
+		OkCodes:         []int{200},
+		MinMicroversion: minMicroversion,
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
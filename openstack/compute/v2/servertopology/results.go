@@ -0,0 +1,46 @@
+package servertopology
+
+import "github.com/gophercloud/gophercloud/v2"
+
+// Topology is the NUMA topology of a server.
+type Topology struct {
+	// Nodes are the NUMA nodes of the server.
+	Nodes []Node `json:"nodes"`
+
+	// PageSizeKB is the page size of the server memory in KiB, if the server
+	// uses huge pages.
+	PageSizeKB *int `json:"pagesize_kb"`
+}
+
+// Node is a NUMA node of a server.
+type Node struct {
+	// MemoryMB is the amount of memory of the node in MiB.
+	MemoryMB int `json:"memory_mb"`
+
+	// Siblings are the sets of vCPUs which are thread siblings.
+	Siblings [][]int `json:"siblings"`
+
+	// VCPUSet are the vCPUs of the node.
+	VCPUSet []int `json:"vcpu_set"`
+
+	// HostNode is the host NUMA node the node is placed on. It is only
+	// visible to administrators.
+	HostNode *int `json:"host_node"`
+
+	// CPUPinning maps the vCPUs of the node to the host CPUs they are pinned
+	// to. It is only visible to administrators.
+	CPUPinning map[int]int `json:"cpu_pinning"`
+}
+
+// GetResult is the response from a Get operation. Call its Extract method to
+// interpret it as a Topology.
+type GetResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets a GetResult as a Topology.
+func (r GetResult) Extract() (*Topology, error) {
+	var s Topology
+	err := r.ExtractInto(&s)
+	return &s, err
+}
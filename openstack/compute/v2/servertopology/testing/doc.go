@@ -0,0 +1,2 @@
+// servertopology unit tests
+package testing
@@ -0,0 +1,71 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servertopology"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+// GetOutput is a sample response to a Get request, as seen by an
+// administrator.
+const GetOutput = `
+{
+    "nodes": [
+        {
+            "cpu_pinning": {
+                "0": 0,
+                "1": 5
+            },
+            "host_node": 0,
+            "memory_mb": 1024,
+            "siblings": [
+                [
+                    0,
+                    1
+                ]
+            ],
+            "vcpu_set": [
+                0,
+                1
+            ]
+        }
+    ],
+    "pagesize_kb": 4
+}
+`
+
+var (
+	hostNode   = 0
+	pageSizeKB = 4
+)
+
+// ExpectedTopology is the topology in GetOutput.
+var ExpectedTopology = servertopology.Topology{
+	Nodes: []servertopology.Node{
+		{
+			MemoryMB:   1024,
+			Siblings:   [][]int{{0, 1}},
+			VCPUSet:    []int{0, 1},
+			HostNode:   &hostNode,
+			CPUPinning: map[int]int{0: 0, 1: 5},
+		},
+	},
+	PageSizeKB: &pageSizeKB,
+}
+
+// HandleGetSuccessfully configures the test server to respond to a Get
+// request.
+func HandleGetSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/servers/b16ba811-199d-4ffd-8839-ba96c1185a67/topology", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestHeader(t, r, "X-OpenStack-Nova-API-Version", "2.78")
+
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, GetOutput)
+	})
+}
@@ -0,0 +1,42 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servertopology"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+func TestGet(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetSuccessfully(t)
+
+	sc := client.ServiceClient()
+	sc.Type = "compute"
+	sc.Microversion = "2.78"
+
+	actual, err := servertopology.Get(context.TODO(), sc, "b16ba811-199d-4ffd-8839-ba96c1185a67").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedTopology, *actual)
+}
+
+func TestGetMicroversionTooLow(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	sc := client.ServiceClient()
+	sc.Type = "compute"
+	sc.Microversion = "2.60"
+
+	_, err := servertopology.Get(context.TODO(), sc, "b16ba811-199d-4ffd-8839-ba96c1185a67").Extract()
+	var mvErr gophercloud.ErrMicroversionNotSupported
+	if !errors.As(err, &mvErr) {
+		t.Fatalf("Expected ErrMicroversionNotSupported, got %v", err)
+	}
+	th.CheckEquals(t, "2.78", mvErr.Required)
+}
@@ -0,0 +1,7 @@
+package servertopology
+
+import "github.com/gophercloud/gophercloud/v2"
+
+func getURL(client *gophercloud.ServiceClient, serverID string) string {
+	return client.ServiceURL("servers", serverID, "topology")
+}
@@ -0,0 +1,56 @@
+/*
+Package userdata composes cloud-init user data for servers from typed parts,
+and decodes existing user data back into its parts.
+
+The Compute service limits user data to 65535 bytes once base64-encoded.
+UserData.Build checks the limit, and can gzip the user data to fit more in.
+
+Example to Compose User Data for a New Server
+
+	cloudConfig, err := userdata.NewCloudConfig(map[string]any{
+		"packages": []string{"nginx"},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	ud := userdata.UserData{
+		Parts: []userdata.Part{
+			cloudConfig,
+			userdata.NewShellScript("systemctl enable --now nginx\n"),
+			userdata.NewIncludeURL("https://example.com/bootstrap.cfg"),
+		},
+		Compress: true,
+	}
+
+	b, err := ud.Build()
+	if err != nil {
+		panic(err)
+	}
+
+	createOpts := servers.CreateOpts{
+		Name:      "server_name",
+		ImageRef:  "image-uuid",
+		FlavorRef: "flavor-uuid",
+		UserData:  b,
+	}
+
+Example to Inspect the User Data of a Server
+
+	computeClient.Microversion = "2.3"
+
+	server, err := servers.Get(context.TODO(), computeClient, serverID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	parts, err := userdata.Parse([]byte(*server.Userdata))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, part := range parts {
+		fmt.Printf("%s (%s): %d bytes\n", part.Filename, part.ContentType, len(part.Content))
+	}
+*/
+package userdata
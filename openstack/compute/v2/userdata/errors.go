@@ -0,0 +1,27 @@
+package userdata
+
+import "fmt"
+
+// ErrTooLarge is returned when user data, once base64-encoded, exceeds the
+// limit of the Compute service.
+type ErrTooLarge struct {
+	// Size is the base64-encoded size of the user data.
+	Size int
+
+	// Limit is the maximum base64-encoded size.
+	Limit int
+}
+
+func (e ErrTooLarge) Error() string {
+	return fmt.Sprintf("User data is %d bytes once base64-encoded, exceeding the limit of %d bytes; consider enabling compression", e.Size, e.Limit)
+}
+
+// ErrEmptyPart is returned when a part has no content type or no content.
+type ErrEmptyPart struct {
+	// Index is the position of the part.
+	Index int
+}
+
+func (e ErrEmptyPart) Error() string {
+	return fmt.Sprintf("User data part %d must have a content type and content", e.Index)
+}
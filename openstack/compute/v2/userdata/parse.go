@@ -0,0 +1,112 @@
+package userdata
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// Parse decodes user data back into its parts, e.g. the user data of an
+// existing server. The data may be base64-encoded and gzipped. Data which is
+// not a multi-part MIME document is returned as a single part, whose content
+// type is derived from its first line the way cloud-init does.
+func Parse(data []byte) ([]Part, error) {
+	if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data))); err == nil && len(decoded) > 0 {
+		data = decoded
+	}
+
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		data, err = io.ReadAll(zr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !bytes.HasPrefix(data, []byte("Content-Type:")) && !bytes.HasPrefix(data, []byte("MIME-Version:")) {
+		return []Part{{ContentType: detectContentType(data), Content: data}}, nil
+	}
+
+	tp := textproto.NewReader(bufio.NewReader(bytes.NewReader(data)))
+	header, err := tp.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		body, err := io.ReadAll(tp.R)
+		if err != nil {
+			return nil, err
+		}
+		return []Part{{ContentType: ContentType(mediaType), Content: body}}, nil
+	}
+
+	var parts []Part
+	mr := multipart.NewReader(tp.R, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := io.ReadAll(p)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(p.Header.Get("Content-Transfer-Encoding"), "base64") {
+			content, err = base64.StdEncoding.DecodeString(string(bytes.Join(bytes.Fields(content), nil)))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		contentType := detectContentType(content)
+		if v := p.Header.Get("Content-Type"); v != "" {
+			if t, _, err := mime.ParseMediaType(v); err == nil {
+				contentType = ContentType(t)
+			}
+		}
+
+		parts = append(parts, Part{
+			ContentType: contentType,
+			Filename:    p.FileName(),
+			Content:     content,
+		})
+	}
+
+	return parts, nil
+}
+
+// detectContentType derives the content type of a single part from its
+// first line.
+func detectContentType(content []byte) ContentType {
+	switch {
+	case bytes.HasPrefix(content, []byte("#cloud-config")):
+		return CloudConfig
+	case bytes.HasPrefix(content, []byte("#cloud-boothook")):
+		return CloudBoothook
+	case bytes.HasPrefix(content, []byte("#include")):
+		return IncludeURL
+	case bytes.HasPrefix(content, []byte("#!")):
+		return ShellScript
+	default:
+		return PlainText
+	}
+}
@@ -0,0 +1,182 @@
+package testing
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/userdata"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+const expectedMultipart = "Content-Type: multipart/mixed; boundary=\"BOUNDARY\"\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Disposition: attachment; filename=\"part-001\"\r\n" +
+	"Content-Transfer-Encoding: 8bit\r\n" +
+	"Content-Type: text/cloud-config; charset=\"utf-8\"\r\n" +
+	"Mime-Version: 1.0\r\n" +
+	"\r\n" +
+	"#cloud-config\n" +
+	"packages:\n" +
+	"- nginx\n" +
+	"\r\n" +
+	"--BOUNDARY\r\n" +
+	"Content-Disposition: attachment; filename=\"start.sh\"\r\n" +
+	"Content-Transfer-Encoding: 8bit\r\n" +
+	"Content-Type: text/x-shellscript; charset=\"utf-8\"\r\n" +
+	"Mime-Version: 1.0\r\n" +
+	"\r\n" +
+	"#!/bin/sh\n" +
+	"systemctl start nginx\n" +
+	"\r\n" +
+	"--BOUNDARY--\r\n"
+
+func newParts(t *testing.T) []userdata.Part {
+	cloudConfig, err := userdata.NewCloudConfig(map[string]any{
+		"packages": []string{"nginx"},
+	})
+	th.AssertNoErr(t, err)
+
+	script := userdata.NewShellScript("systemctl start nginx\n")
+	script.Filename = "start.sh"
+
+	return []userdata.Part{cloudConfig, script}
+}
+
+func TestBuild(t *testing.T) {
+	ud := userdata.UserData{
+		Parts:    newParts(t),
+		Boundary: "BOUNDARY",
+	}
+
+	actual, err := ud.Build()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, expectedMultipart, string(actual))
+}
+
+func TestBuildCompressed(t *testing.T) {
+	ud := userdata.UserData{
+		Parts:    newParts(t),
+		Compress: true,
+		Boundary: "BOUNDARY",
+	}
+
+	actual, err := ud.Build()
+	th.AssertNoErr(t, err)
+
+	zr, err := gzip.NewReader(bytes.NewReader(actual))
+	th.AssertNoErr(t, err)
+	decompressed, err := io.ReadAll(zr)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, expectedMultipart, string(decompressed))
+}
+
+func TestBuildEmptyPart(t *testing.T) {
+	ud := userdata.UserData{
+		Parts: []userdata.Part{{ContentType: userdata.ShellScript}},
+	}
+
+	_, err := ud.Build()
+	var emptyErr userdata.ErrEmptyPart
+	if !errors.As(err, &emptyErr) {
+		t.Fatalf("Expected ErrEmptyPart, got %v", err)
+	}
+	th.CheckEquals(t, 0, emptyErr.Index)
+}
+
+func TestBuildTooLarge(t *testing.T) {
+	// Random content does not compress, so it does not fit either way.
+	content := make([]byte, 60000)
+	_, err := rand.Read(content)
+	th.AssertNoErr(t, err)
+
+	for _, compress := range []bool{false, true} {
+		ud := userdata.UserData{
+			Parts:    []userdata.Part{{ContentType: userdata.PlainText, Content: content}},
+			Compress: compress,
+		}
+
+		_, err := ud.Build()
+		var tooLarge userdata.ErrTooLarge
+		if !errors.As(err, &tooLarge) {
+			t.Fatalf("Expected ErrTooLarge, got %v", err)
+		}
+		th.CheckEquals(t, userdata.MaxSize, tooLarge.Limit)
+	}
+}
+
+func TestBuildCompressionFits(t *testing.T) {
+	script := userdata.NewShellScript(strings.Repeat("echo hello\n", 10000))
+
+	_, err := userdata.UserData{Parts: []userdata.Part{script}}.Build()
+	if !errors.As(err, &userdata.ErrTooLarge{}) {
+		t.Fatalf("Expected ErrTooLarge, got %v", err)
+	}
+
+	_, err = userdata.UserData{Parts: []userdata.Part{script}, Compress: true}.Build()
+	th.AssertNoErr(t, err)
+}
+
+func TestParse(t *testing.T) {
+	expected := newParts(t)
+	expected[0].Filename = "part-001"
+
+	ud := userdata.UserData{
+		Parts:    newParts(t),
+		Compress: true,
+	}
+	b, err := ud.Build()
+	th.AssertNoErr(t, err)
+
+	// Raw, as built.
+	actual, err := userdata.Parse(b)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, expected, actual)
+
+	// Base64-encoded, as returned by the Compute service.
+	actual, err = userdata.Parse([]byte(base64.StdEncoding.EncodeToString(b)))
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, expected, actual)
+}
+
+func TestParseSinglePart(t *testing.T) {
+	for content, contentType := range map[string]userdata.ContentType{
+		"#cloud-config\npackages: [nginx]\n": userdata.CloudConfig,
+		"#!/bin/bash\necho hello\n":          userdata.ShellScript,
+		"#cloud-boothook\necho hello\n":      userdata.CloudBoothook,
+		"#include\nhttps://example.com\n":    userdata.IncludeURL,
+		"some data\n":                        userdata.PlainText,
+	} {
+		actual, err := userdata.Parse([]byte(base64.StdEncoding.EncodeToString([]byte(content))))
+		th.AssertNoErr(t, err)
+		th.CheckDeepEquals(t, []userdata.Part{{ContentType: contentType, Content: []byte(content)}}, actual)
+	}
+}
+
+func TestParseBase64Part(t *testing.T) {
+	data := "Content-Type: multipart/mixed; boundary=\"XYZ\"\n" +
+		"MIME-Version: 1.0\n" +
+		"\n" +
+		"--XYZ\n" +
+		"Content-Type: text/x-shellscript; charset=\"utf-8\"\n" +
+		"Content-Transfer-Encoding: base64\n" +
+		"Content-Disposition: attachment; filename=\"hello.sh\"\n" +
+		"\n" +
+		base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\necho héllo\n")) + "\n" +
+		"--XYZ--\n"
+
+	actual, err := userdata.Parse([]byte(data))
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []userdata.Part{{
+		ContentType: userdata.ShellScript,
+		Filename:    "hello.sh",
+		Content:     []byte("#!/bin/sh\necho héllo\n"),
+	}}, actual)
+}
@@ -0,0 +1,183 @@
+package userdata
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// MaxSize is the maximum size of base64-encoded user data accepted by the
+// Compute service.
+const MaxSize = 65535
+
+// ContentType is the MIME type of a user data part, which tells cloud-init
+// how to handle it.
+type ContentType string
+
+const (
+	// CloudConfig is a cloud-config YAML document.
+	CloudConfig ContentType = "text/cloud-config"
+
+	// ShellScript is a script run once, late in the first boot.
+	ShellScript ContentType = "text/x-shellscript"
+
+	// CloudBoothook is a script run early on every boot.
+	CloudBoothook ContentType = "text/cloud-boothook"
+
+	// IncludeURL is a list of URLs whose content is fetched and processed as
+	// user data.
+	IncludeURL ContentType = "text/x-include-url"
+
+	// PlainText is content cloud-init does not handle, e.g. data for
+	// other tools.
+	PlainText ContentType = "text/plain"
+)
+
+// Part is a single part of multi-part user data.
+type Part struct {
+	// ContentType is the MIME type of the part.
+	ContentType ContentType
+
+	// Filename is the name of the part. cloud-init uses it to name the
+	// files it writes scripts to. It defaults to part-NNN.
+	Filename string
+
+	// Content is the content of the part.
+	Content []byte
+}
+
+// NewCloudConfig returns a cloud-config part. config is either the YAML
+// document, as a string or []byte, or a value which is marshalled to YAML.
+// The "#cloud-config" header is added if missing.
+func NewCloudConfig(config any) (Part, error) {
+	var b []byte
+	switch v := config.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		var err error
+		b, err = yaml.Marshal(config)
+		if err != nil {
+			return Part{}, err
+		}
+	}
+
+	if !bytes.HasPrefix(b, []byte("#cloud-config")) {
+		b = append([]byte("#cloud-config\n"), b...)
+	}
+	return Part{ContentType: CloudConfig, Content: b}, nil
+}
+
+// NewShellScript returns a shell script part. A "#!/bin/sh" interpreter line
+// is added if the script has none.
+func NewShellScript(script string) Part {
+	if !strings.HasPrefix(script, "#!") {
+		script = "#!/bin/sh\n" + script
+	}
+	return Part{ContentType: ShellScript, Content: []byte(script)}
+}
+
+// NewCloudBoothook returns a boothook part.
+func NewCloudBoothook(script string) Part {
+	return Part{ContentType: CloudBoothook, Content: []byte(script)}
+}
+
+// NewIncludeURL returns a part including the content of the given URLs.
+func NewIncludeURL(urls ...string) Part {
+	return Part{ContentType: IncludeURL, Content: []byte(strings.Join(urls, "\n") + "\n")}
+}
+
+// UserData composes cloud-init multi-part user data.
+type UserData struct {
+	// Parts are the parts of the user data, in the order cloud-init
+	// processes them.
+	Parts []Part
+
+	// Compress gzips the user data, which cloud-init transparently
+	// decompresses.
+	Compress bool
+
+	// Boundary is the MIME boundary between parts. A random boundary is
+	// used when empty.
+	Boundary string
+}
+
+// Build assembles the user data into a multi-part MIME document, compresses
+// it if requested and checks that it fits the Compute service limit. The
+// result can be used as servers.CreateOpts.UserData as is: servers.Create
+// base64-encodes it.
+func (u UserData) Build() ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if u.Boundary != "" {
+		if err := mw.SetBoundary(u.Boundary); err != nil {
+			return nil, err
+		}
+	}
+
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", mw.Boundary())
+
+	for i, p := range u.Parts {
+		if p.ContentType == "" || len(p.Content) == 0 {
+			return nil, ErrEmptyPart{Index: i}
+		}
+
+		filename := p.Filename
+		if filename == "" {
+			filename = fmt.Sprintf("part-%03d", i+1)
+		}
+
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Type", fmt.Sprintf("%s; charset=\"utf-8\"", p.ContentType))
+		h.Set("MIME-Version", "1.0")
+		h.Set("Content-Transfer-Encoding", "8bit")
+		h.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		w, err := mw.CreatePart(h)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(p.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	b := buf.Bytes()
+	if u.Compress {
+		var gz bytes.Buffer
+		zw := gzip.NewWriter(&gz)
+		if _, err := zw.Write(b); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		b = gz.Bytes()
+	}
+
+	if err := Validate(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Validate checks that raw user data fits the Compute service limit once
+// base64-encoded.
+func Validate(data []byte) error {
+	if size := base64.StdEncoding.EncodedLen(len(data)); size > MaxSize {
+		return ErrTooLarge{Size: size, Limit: MaxSize}
+	}
+	return nil
+}
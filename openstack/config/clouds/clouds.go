@@ -17,15 +17,31 @@
 //	if err != nil {
 //		panic(err)
 //	}
+//
+// To honor the per-service settings of the cloud entry, such as
+// `network_endpoint_override` or `block_storage_api_version`, use ParseCloud
+// and config.NewServiceClient instead of EndpointOpts:
+//
+//	cloud, err := clouds.ParseCloud()
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	networkClient, err := config.NewServiceClient(providerClient, cloud, "network")
+//	if err != nil {
+//		panic(err)
+//	}
 package clouds
 
 import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path"
 	"reflect"
+	"strings"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"gopkg.in/yaml.v2"
@@ -46,6 +62,13 @@ import (
 //
 // Once `clouds.yaml` is found in a search location, the same location is used to search for `secure.yaml`.
 //
+// If the cloud entry references a vendor profile with the `profile` key (or
+// the deprecated `cloud` key), the profile is read from the `public-clouds`
+// section of `clouds-public.yaml`, which is searched for in the default
+// search locations listed above. The values of the profile are overridden by
+// the ones of the cloud entry, which are in turn overridden by the ones of
+// `secure.yaml`.
+//
 // Like in python-openstackclient, relative paths in the `clouds.yaml` section
 // `cacert` are interpreted as relative the the current directory, and not to
 // the `clouds.yaml` location.
@@ -53,6 +76,72 @@ import (
 // Search locations, as well as individual `clouds.yaml` properties, can be
 // overwritten with functional options.
 func Parse(opts ...ParseOption) (gophercloud.AuthOptions, gophercloud.EndpointOpts, *tls.Config, error) {
+	options := newCloudOpts(opts)
+
+	cloud, err := loadCloud(options)
+	if err != nil {
+		return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, err
+	}
+
+	tlsConfig, err := computeTLSConfig(cloud, options)
+	if err != nil {
+		return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("unable to compute TLS configuration: %w", err)
+	}
+
+	endpointType := coalesce(options.endpointType, cloud.EndpointType, cloud.Interface)
+
+	var scope *gophercloud.AuthScope
+	if trustID := cloud.AuthInfo.TrustID; trustID != "" {
+		scope = &gophercloud.AuthScope{
+			TrustID: trustID,
+		}
+	}
+
+	return gophercloud.AuthOptions{
+			IdentityEndpoint:            coalesce(options.authURL, cloud.AuthInfo.AuthURL),
+			Username:                    coalesce(options.username, cloud.AuthInfo.Username),
+			UserID:                      coalesce(options.userID, cloud.AuthInfo.UserID),
+			Password:                    coalesce(options.password, cloud.AuthInfo.Password),
+			DomainID:                    coalesce(options.domainID, cloud.AuthInfo.UserDomainID, cloud.AuthInfo.ProjectDomainID, cloud.AuthInfo.DomainID),
+			DomainName:                  coalesce(options.domainName, cloud.AuthInfo.UserDomainName, cloud.AuthInfo.ProjectDomainName, cloud.AuthInfo.DomainName),
+			TenantID:                    coalesce(options.projectID, cloud.AuthInfo.ProjectID),
+			TenantName:                  coalesce(options.projectName, cloud.AuthInfo.ProjectName),
+			TokenID:                     coalesce(options.token, cloud.AuthInfo.Token),
+			Scope:                       coalesce(options.scope, scope),
+			ApplicationCredentialID:     coalesce(options.applicationCredentialID, cloud.AuthInfo.ApplicationCredentialID),
+			ApplicationCredentialName:   coalesce(options.applicationCredentialName, cloud.AuthInfo.ApplicationCredentialName),
+			ApplicationCredentialSecret: coalesce(options.applicationCredentialSecret, cloud.AuthInfo.ApplicationCredentialSecret),
+			OIDC:                        computeOIDC(cloud),
+		}, gophercloud.EndpointOpts{
+			Region:       coalesce(options.region, cloud.RegionName),
+			Availability: computeAvailability(endpointType),
+		},
+		tlsConfig,
+		nil
+}
+
+// ParseCloud fetches a clouds.yaml file from disk like Parse, and returns the
+// cloud entry, merged with its vendor profile and with secure.yaml. The region
+// and the interface set with functional options or environment variables
+// override the ones of the entry.
+//
+// Unlike the EndpointOpts returned by Parse, the returned Cloud holds the
+// per-service settings of the entry, which can be read with
+// Cloud.ServiceConfig.
+func ParseCloud(opts ...ParseOption) (Cloud, error) {
+	options := newCloudOpts(opts)
+
+	cloud, err := loadCloud(options)
+	if err != nil {
+		return Cloud{}, err
+	}
+
+	cloud.RegionName = coalesce(options.region, cloud.RegionName)
+	cloud.EndpointType = coalesce(options.endpointType, cloud.EndpointType, cloud.Interface)
+	return cloud, nil
+}
+
+func newCloudOpts(opts []ParseOption) cloudOpts {
 	options := cloudOpts{
 		cloudName:    os.Getenv("OS_CLOUD"),
 		region:       os.Getenv("OS_REGION_NAME"),
@@ -68,24 +157,25 @@ func Parse(opts ...ParseOption) (gophercloud.AuthOptions, gophercloud.EndpointOp
 	for _, apply := range opts {
 		apply(&options)
 	}
+	return options
+}
 
+// loadCloud reads the cloud entry selected by options from clouds.yaml, and
+// merges it with secure.yaml and with its vendor profile.
+func loadCloud(options cloudOpts) (Cloud, error) {
 	if options.cloudName == "" {
-		return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("the empty string \"\" is not a valid cloud name")
+		return Cloud{}, fmt.Errorf("the empty string \"\" is not a valid cloud name")
 	}
 
 	// Set the defaults and open the files for reading. This code only runs
 	// if no override has been set, because it is fallible.
 	if options.cloudsyamlReader == nil {
 		if len(options.locations) < 1 {
-			cwd, err := os.Getwd()
+			locations, err := defaultLocations("clouds.yaml")
 			if err != nil {
-				return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("failed to get the current working directory: %w", err)
+				return Cloud{}, err
 			}
-			userConfig, err := os.UserConfigDir()
-			if err != nil {
-				return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("failed to get the user config directory: %w", err)
-			}
-			options.locations = []string{path.Join(cwd, "clouds.yaml"), path.Join(userConfig, "openstack", "clouds.yaml"), path.Join("/etc", "openstack", "clouds.yaml")}
+			options.locations = locations
 		}
 
 		for _, cloudsPath := range options.locations {
@@ -107,74 +197,148 @@ func Parse(opts ...ParseOption) (gophercloud.AuthOptions, gophercloud.EndpointOp
 			break
 		}
 		if options.cloudsyamlReader == nil {
-			return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("clouds file not found. Search locations were: %v", options.locations)
+			return Cloud{}, fmt.Errorf("clouds file not found. Search locations were: %v", options.locations)
 		}
 	}
 
 	// Parse the YAML payloads.
 	var clouds Clouds
 	if err := yaml.NewDecoder(options.cloudsyamlReader).Decode(&clouds); err != nil {
-		return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, err
+		return Cloud{}, err
 	}
 
 	cloud, ok := clouds.Clouds[options.cloudName]
 	if !ok {
-		return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("cloud %q not found in clouds.yaml", options.cloudName)
+		return Cloud{}, fmt.Errorf("cloud %q not found in clouds.yaml", options.cloudName)
 	}
+	normalizeServiceKeys(&cloud)
 
 	if options.secureyamlReader != nil {
 		var secureClouds Clouds
 		if err := yaml.NewDecoder(options.secureyamlReader).Decode(&secureClouds); err != nil {
-			return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("failed to parse secure.yaml: %w", err)
+			return Cloud{}, fmt.Errorf("failed to parse secure.yaml: %w", err)
 		}
 
 		if secureCloud, ok := secureClouds.Clouds[options.cloudName]; ok {
+			normalizeServiceKeys(&secureCloud)
 			// If secureCloud has content and it differs from the cloud entry,
 			// merge the two together.
 			if !reflect.DeepEqual((gophercloud.AuthOptions{}), secureClouds) && !reflect.DeepEqual(clouds, secureClouds) {
 				var err error
 				cloud, err = mergeClouds(secureCloud, cloud)
 				if err != nil {
-					return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("unable to merge information from clouds.yaml and secure.yaml")
+					return Cloud{}, fmt.Errorf("unable to merge information from clouds.yaml and secure.yaml")
 				}
 			}
 		}
 	}
 
-	tlsConfig, err := computeTLSConfig(cloud, options)
-	if err != nil {
-		return gophercloud.AuthOptions{}, gophercloud.EndpointOpts{}, nil, fmt.Errorf("unable to compute TLS configuration: %w", err)
+	if profileName := coalesce(cloud.Profile, cloud.Cloud); profileName != "" {
+		var err error
+		cloud, err = applyProfile(cloud, profileName, options)
+		if err != nil {
+			return Cloud{}, err
+		}
 	}
+	return cloud, nil
+}
 
-	endpointType := coalesce(options.endpointType, cloud.EndpointType, cloud.Interface)
+// applyProfile merges the vendor profile profileName from clouds-public.yaml
+// into cloud. Values set in cloud take precedence over the ones of the
+// profile, like values set in secure.yaml take precedence over the ones of
+// clouds.yaml.
+func applyProfile(cloud Cloud, profileName string, options cloudOpts) (Cloud, error) {
+	if options.publicyamlReader == nil {
+		if len(options.publicLocations) < 1 {
+			locations, err := defaultLocations("clouds-public.yaml")
+			if err != nil {
+				return Cloud{}, err
+			}
+			options.publicLocations = locations
+		}
 
-	var scope *gophercloud.AuthScope
-	if trustID := cloud.AuthInfo.TrustID; trustID != "" {
-		scope = &gophercloud.AuthScope{
-			TrustID: trustID,
+		for _, publicPath := range options.publicLocations {
+			f, err := os.Open(publicPath)
+			if err != nil {
+				continue
+			}
+			defer f.Close()
+			options.publicyamlReader = f
+			break
+		}
+		if options.publicyamlReader == nil {
+			return Cloud{}, fmt.Errorf("cloud %q uses profile %q, but no clouds-public.yaml was found. Search locations were: %v", options.cloudName, profileName, options.publicLocations)
 		}
 	}
 
-	return gophercloud.AuthOptions{
-			IdentityEndpoint:            coalesce(options.authURL, cloud.AuthInfo.AuthURL),
-			Username:                    coalesce(options.username, cloud.AuthInfo.Username),
-			UserID:                      coalesce(options.userID, cloud.AuthInfo.UserID),
-			Password:                    coalesce(options.password, cloud.AuthInfo.Password),
-			DomainID:                    coalesce(options.domainID, cloud.AuthInfo.UserDomainID, cloud.AuthInfo.ProjectDomainID, cloud.AuthInfo.DomainID),
-			DomainName:                  coalesce(options.domainName, cloud.AuthInfo.UserDomainName, cloud.AuthInfo.ProjectDomainName, cloud.AuthInfo.DomainName),
-			TenantID:                    coalesce(options.projectID, cloud.AuthInfo.ProjectID),
-			TenantName:                  coalesce(options.projectName, cloud.AuthInfo.ProjectName),
-			TokenID:                     coalesce(options.token, cloud.AuthInfo.Token),
-			Scope:                       coalesce(options.scope, scope),
-			ApplicationCredentialID:     coalesce(options.applicationCredentialID, cloud.AuthInfo.ApplicationCredentialID),
-			ApplicationCredentialName:   coalesce(options.applicationCredentialName, cloud.AuthInfo.ApplicationCredentialName),
-			ApplicationCredentialSecret: coalesce(options.applicationCredentialSecret, cloud.AuthInfo.ApplicationCredentialSecret),
-		}, gophercloud.EndpointOpts{
-			Region:       coalesce(options.region, cloud.RegionName),
-			Availability: computeAvailability(endpointType),
-		},
-		tlsConfig,
-		nil
+	var publicClouds PublicClouds
+	if err := yaml.NewDecoder(options.publicyamlReader).Decode(&publicClouds); err != nil {
+		return Cloud{}, fmt.Errorf("failed to parse clouds-public.yaml: %w", err)
+	}
+
+	profile, ok := publicClouds.PublicClouds[profileName]
+	if !ok {
+		return Cloud{}, fmt.Errorf("profile %q not found in clouds-public.yaml", profileName)
+	}
+	normalizeServiceKeys(&profile)
+
+	// Regions are a list, which mergeClouds would concatenate. The regions of
+	// the cloud replace the ones of the profile instead.
+	if len(cloud.Regions) > 0 {
+		profile.Regions = nil
+	}
+
+	merged, err := mergeClouds(cloud, profile)
+	if err != nil {
+		return Cloud{}, fmt.Errorf("unable to merge information from clouds.yaml and profile %q: %w", profileName, err)
+	}
+	return merged, nil
+}
+
+// defaultLocations returns the default search locations for the given
+// configuration file.
+func defaultLocations(filename string) ([]string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current working directory: %w", err)
+	}
+	userConfig, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the user config directory: %w", err)
+	}
+	return []string{path.Join(cwd, filename), path.Join(userConfig, "openstack", filename), path.Join("/etc", "openstack", filename)}, nil
+}
+
+// computeOIDC returns the OpenID Connect options of the cloud, or nil if its
+// auth type is not one of the OpenID Connect ones.
+func computeOIDC(cloud Cloud) *gophercloud.OIDCAuthOptions {
+	var grantType gophercloud.OIDCGrantType
+	switch cloud.AuthType {
+	case AuthV3OIDCPassword:
+		grantType = gophercloud.OIDCGrantPassword
+	case AuthV3OIDCClientCredentials:
+		grantType = gophercloud.OIDCGrantClientCredentials
+	case AuthV3OIDCAuthCode:
+		grantType = gophercloud.OIDCGrantAuthorizationCode
+	case AuthV3OIDCAccessToken:
+	default:
+		return nil
+	}
+
+	return &gophercloud.OIDCAuthOptions{
+		IdentityProvider:    cloud.AuthInfo.IdentityProvider,
+		Protocol:            cloud.AuthInfo.Protocol,
+		GrantType:           grantType,
+		AccessToken:         cloud.AuthInfo.AccessToken,
+		ClientID:            cloud.AuthInfo.ClientID,
+		ClientSecret:        cloud.AuthInfo.ClientSecret,
+		DiscoveryEndpoint:   cloud.AuthInfo.DiscoveryEndpoint,
+		AccessTokenEndpoint: cloud.AuthInfo.AccessTokenEndpoint,
+		AccessTokenType:     cloud.AuthInfo.AccessTokenType,
+		Scopes:              strings.Fields(cloud.AuthInfo.OpenIDScope),
+		AuthorizationCode:   cloud.AuthInfo.Code,
+		RedirectURI:         cloud.AuthInfo.RedirectURI,
+	}
 }
 
 // computeAvailability is a helper method to determine the endpoint type
@@ -232,6 +396,19 @@ func mergeClouds(override, cloud Cloud) (Cloud, error) {
 	if err != nil {
 		return Cloud{}, err
 	}
+
+	// Extra is not encoded in JSON, and is merged separately.
+	if len(override.Extra) > 0 || len(cloud.Extra) > 0 {
+		mergedCloud.Extra = make(map[string]any, len(cloud.Extra))
+		maps.Copy(mergedCloud.Extra, cloud.Extra)
+		for k, v := range override.Extra {
+			if cloudValue, ok := mergedCloud.Extra[k]; ok {
+				mergedCloud.Extra[k] = mergeInterfaces(v, cloudValue)
+			} else {
+				mergedCloud.Extra[k] = v
+			}
+		}
+	}
 	return mergedCloud, nil
 }
 
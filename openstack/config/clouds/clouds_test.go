@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack/config/clouds"
 )
 
@@ -223,3 +224,262 @@ func TestParse(t *testing.T) {
 		}
 	})
 }
+
+func TestParseProfile(t *testing.T) {
+	const publicCloudsYAML = `public-clouds:
+  example-vendor:
+    auth:
+      auth_url: https://identity.example.com/v3
+      user_domain_name: Default
+    region_name: RegionOne
+    interface: internal
+    identity_api_version: "3"`
+
+	t.Run("merges the vendor profile into the cloud", func(t *testing.T) {
+		const cloudsYAML = `clouds:
+  gophercloud-test:
+    profile: example-vendor
+    region_name: RegionTwo
+    auth:
+      username: gophercloud-test-username
+      project_name: gophercloud-test-project`
+		const secureYAML = `clouds:
+  gophercloud-test:
+    auth:
+      password: secret`
+
+		ao, eo, _, err := clouds.Parse(
+			clouds.WithCloudName("gophercloud-test"),
+			clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+			clouds.WithSecureYAML(strings.NewReader(secureYAML)),
+			clouds.WithPublicCloudsYAML(strings.NewReader(publicCloudsYAML)),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := ao.IdentityEndpoint; got != "https://identity.example.com/v3" {
+			t.Errorf("unexpected identity endpoint: %q", got)
+		}
+		if got := ao.DomainName; got != "Default" {
+			t.Errorf("unexpected domain name: %q", got)
+		}
+		if got := ao.Username; got != "gophercloud-test-username" {
+			t.Errorf("unexpected username: %q", got)
+		}
+		if got := ao.Password; got != "secret" {
+			t.Errorf("unexpected password: %q", got)
+		}
+		if got := eo.Region; got != "RegionTwo" {
+			t.Errorf("unexpected region: %q", got)
+		}
+		if got := eo.Availability; got != "internal" {
+			t.Errorf("unexpected availability: %q", got)
+		}
+	})
+
+	t.Run("resolves the deprecated cloud key", func(t *testing.T) {
+		const cloudsYAML = `clouds:
+  gophercloud-test:
+    cloud: example-vendor`
+
+		ao, eo, _, err := clouds.Parse(
+			clouds.WithCloudName("gophercloud-test"),
+			clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+			clouds.WithPublicCloudsYAML(strings.NewReader(publicCloudsYAML)),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := ao.IdentityEndpoint; got != "https://identity.example.com/v3" {
+			t.Errorf("unexpected identity endpoint: %q", got)
+		}
+		if got := eo.Region; got != "RegionOne" {
+			t.Errorf("unexpected region: %q", got)
+		}
+	})
+
+	t.Run("searches clouds-public.yaml in the given locations", func(t *testing.T) {
+		const cloudsYAML = `clouds:
+  gophercloud-test:
+    profile: example-vendor`
+
+		tmpDir := t.TempDir()
+		missingPath, publicPath := path.Join(tmpDir, "missing", "clouds-public.yaml"), path.Join(tmpDir, "clouds-public.yaml")
+		if err := os.WriteFile(publicPath, []byte(publicCloudsYAML), 0644); err != nil {
+			t.Fatalf("unable to create a mock clouds-public.yaml file: %v", err)
+		}
+
+		ao, _, _, err := clouds.Parse(
+			clouds.WithCloudName("gophercloud-test"),
+			clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+			clouds.WithPublicCloudsLocations(missingPath, publicPath),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := ao.IdentityEndpoint; got != "https://identity.example.com/v3" {
+			t.Errorf("unexpected identity endpoint: %q", got)
+		}
+	})
+
+	t.Run("fails if the profile does not exist", func(t *testing.T) {
+		const cloudsYAML = `clouds:
+  gophercloud-test:
+    profile: unknown-vendor`
+
+		_, _, _, err := clouds.Parse(
+			clouds.WithCloudName("gophercloud-test"),
+			clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+			clouds.WithPublicCloudsYAML(strings.NewReader(publicCloudsYAML)),
+		)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestParseCloudServiceConfig(t *testing.T) {
+	const publicCloudsYAML = `public-clouds:
+  example-vendor:
+    auth:
+      auth_url: https://identity.example.com/v3
+    region_name: RegionOne
+    block_storage_api_version: 2
+    image_endpoint_override: https://image.example.com`
+	const cloudsYAML = `clouds:
+  gophercloud-test:
+    profile: example-vendor
+    interface: internal
+    compute_endpoint_override: https://compute.example.com/v2.1
+    compute_api_version: "2.1"
+    network_interface: admin
+    network_region_name: RegionTwo
+    volume_api_version: 3`
+
+	cloud, err := clouds.ParseCloud(
+		clouds.WithCloudName("gophercloud-test"),
+		clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+		clouds.WithPublicCloudsYAML(strings.NewReader(publicCloudsYAML)),
+		clouds.WithRegion(""),
+		clouds.WithEndpointType(""),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, tc := range []struct {
+		serviceType string
+		expected    clouds.ServiceConfig
+	}{
+		{
+			serviceType: "compute",
+			expected: clouds.ServiceConfig{
+				EndpointOverride: "https://compute.example.com/v2.1",
+				Interface:        "internal",
+				APIVersion:       "2.1",
+				RegionName:       "RegionOne",
+			},
+		},
+		{
+			serviceType: "network",
+			expected: clouds.ServiceConfig{
+				Interface:  "admin",
+				RegionName: "RegionTwo",
+			},
+		},
+		{
+			serviceType: "block-storage",
+			expected: clouds.ServiceConfig{
+				Interface:  "internal",
+				APIVersion: "3",
+				RegionName: "RegionOne",
+			},
+		},
+		{
+			serviceType: "volumev3",
+			expected: clouds.ServiceConfig{
+				Interface:  "internal",
+				APIVersion: "3",
+				RegionName: "RegionOne",
+			},
+		},
+		{
+			serviceType: "image",
+			expected: clouds.ServiceConfig{
+				EndpointOverride: "https://image.example.com",
+				Interface:        "internal",
+				RegionName:       "RegionOne",
+			},
+		},
+	} {
+		t.Run(tc.serviceType, func(t *testing.T) {
+			if got := cloud.ServiceConfig(tc.serviceType); got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseOIDC(t *testing.T) {
+	const cloudsYAML = `clouds:
+  gophercloud-test:
+    auth_type: v3oidcpassword
+    auth:
+      auth_url: https://identity.example.com/v3
+      identity_provider: myidp
+      protocol: openid
+      client_id: openstack
+      client_secret: client-secret
+      discovery_endpoint: https://idp.example.com/.well-known/openid-configuration
+      openid_scope: openid email
+      username: demo
+      password: secret
+      project_name: demo
+      project_domain_name: Default`
+
+	ao, _, _, err := clouds.Parse(
+		clouds.WithCloudName("gophercloud-test"),
+		clouds.WithCloudsYAML(strings.NewReader(cloudsYAML)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ao.OIDC == nil {
+		t.Fatalf("expected OIDC options")
+	}
+	if got := ao.OIDC.GrantType; got != gophercloud.OIDCGrantPassword {
+		t.Errorf("unexpected grant type: %q", got)
+	}
+	if got := ao.OIDC.IdentityProvider + "/" + ao.OIDC.Protocol; got != "myidp/openid" {
+		t.Errorf("unexpected identity provider and protocol: %q", got)
+	}
+	if got := ao.OIDC.DiscoveryEndpoint; got != "https://idp.example.com/.well-known/openid-configuration" {
+		t.Errorf("unexpected discovery endpoint: %q", got)
+	}
+	if got := strings.Join(ao.OIDC.Scopes, " "); got != "openid email" {
+		t.Errorf("unexpected scopes: %q", got)
+	}
+	if got := ao.Username + ":" + ao.Password; got != "demo:secret" {
+		t.Errorf("unexpected credentials: %q", got)
+	}
+	if got := ao.TenantName; got != "demo" {
+		t.Errorf("unexpected project name: %q", got)
+	}
+
+	t.Run("ignores the OIDC keys of other auth types", func(t *testing.T) {
+		ao, _, _, err := clouds.Parse(
+			clouds.WithCloudName("gophercloud-test"),
+			clouds.WithCloudsYAML(strings.NewReader(strings.Replace(cloudsYAML, "v3oidcpassword", "v3password", 1))),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ao.OIDC != nil {
+			t.Errorf("unexpected OIDC options: %+v", ao.OIDC)
+		}
+	})
+}
@@ -11,6 +11,8 @@ type cloudOpts struct {
 	locations        []string
 	cloudsyamlReader io.Reader
 	secureyamlReader io.Reader
+	publicLocations  []string
+	publicyamlReader io.Reader
 
 	applicationCredentialID     string
 	applicationCredentialName   string
@@ -72,6 +74,26 @@ func WithSecureYAML(secure io.Reader) ParseOption {
 	}
 }
 
+// WithPublicCloudsLocations is a functional option that sets the search
+// locations for the clouds-public.yaml file, which holds the vendor profiles
+// referenced by cloud entries. Each location is a file path pointing to a
+// possible `clouds-public.yaml`.
+func WithPublicCloudsLocations(locations ...string) ParseOption {
+	return func(co *cloudOpts) {
+		co.publicLocations = locations
+	}
+}
+
+// WithPublicCloudsYAML is a functional option that lets you pass a
+// clouds-public.yaml file as an io.Reader interface. When this option is
+// passed, Parse will not attempt to fetch clouds-public.yaml from the file
+// system.
+func WithPublicCloudsYAML(publicClouds io.Reader) ParseOption {
+	return func(co *cloudOpts) {
+		co.publicyamlReader = publicClouds
+	}
+}
+
 func WithApplicationCredentialID(applicationCredentialID string) ParseOption {
 	return func(co *cloudOpts) {
 		co.applicationCredentialID = applicationCredentialID
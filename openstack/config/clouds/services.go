@@ -0,0 +1,149 @@
+package clouds
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// ServiceConfig holds the settings of a cloud entry for a single service, as
+// set with the openstacksdk per-service keys of clouds.yaml:
+//
+//	clouds:
+//	  openstack:
+//	    compute_endpoint_override: https://compute.example.com/v2.1
+//	    network_interface: internal
+//	    block_storage_api_version: 3
+//	    image_region_name: RegionTwo
+//
+// The keys are prefixed with the service type, in which dashes are replaced
+// with underscores. The aliases of the service type are also accepted, for
+// example `volume_api_version` for the block-storage service.
+type ServiceConfig struct {
+	// EndpointOverride is the endpoint of the service, which is used instead
+	// of the one of the service catalog.
+	EndpointOverride string
+
+	// Interface is the interface of the service endpoint: public, internal or
+	// admin. It defaults to the interface of the cloud entry.
+	Interface string
+
+	// APIVersion is the API version of the service, e.g. "3". It is empty
+	// when not set.
+	APIVersion string
+
+	// RegionName is the region of the service endpoint. It defaults to the
+	// region of the cloud entry.
+	RegionName string
+}
+
+// EndpointOpts returns the options to find the service endpoint in the
+// service catalog.
+func (sc ServiceConfig) EndpointOpts() gophercloud.EndpointOpts {
+	return gophercloud.EndpointOpts{
+		Region:       sc.RegionName,
+		Availability: computeAvailability(sc.Interface),
+	}
+}
+
+// ServiceConfig returns the settings of the cloud entry for the given service
+// type.
+func (cloud Cloud) ServiceConfig(serviceType string) ServiceConfig {
+	prefixes := serviceKeyPrefixes(serviceType)
+
+	apiVersion := cloud.serviceValue(prefixes, "api_version")
+	if apiVersion == "" {
+		switch prefixes[0] {
+		case "identity":
+			apiVersion = cloud.IdentityAPIVersion
+		case "block_storage":
+			apiVersion = cloud.VolumeAPIVersion
+		}
+	}
+
+	return ServiceConfig{
+		EndpointOverride: cloud.serviceValue(prefixes, "endpoint_override"),
+		Interface:        coalesce(cloud.serviceValue(prefixes, "interface"), cloud.EndpointType, cloud.Interface),
+		APIVersion:       apiVersion,
+		RegionName:       coalesce(cloud.serviceValue(prefixes, "region_name"), cloud.RegionName),
+	}
+}
+
+// serviceValue returns the value of the first <prefix>_<key> key of the cloud
+// entry that is set.
+func (cloud Cloud) serviceValue(prefixes []string, key string) string {
+	for _, prefix := range prefixes {
+		switch v := cloud.Extra[prefix+"_"+key].(type) {
+		case nil:
+			continue
+		case string:
+			if v != "" {
+				return v
+			}
+		case int, float64, bool:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// serviceKeySuffixes are the suffixes of the per-service keys.
+var serviceKeySuffixes = []string{"endpoint_override", "interface", "api_version", "region_name"}
+
+// normalizeServiceKeys renames the per-service keys of a cloud entry that use
+// an alias of a service type to the official name, so that entries using
+// different names for the same service are merged correctly.
+func normalizeServiceKeys(cloud *Cloud) {
+	if cloud.VolumeAPIVersion != "" {
+		if _, ok := cloud.Extra["block_storage_api_version"]; !ok {
+			if cloud.Extra == nil {
+				cloud.Extra = make(map[string]any)
+			}
+			cloud.Extra["block_storage_api_version"] = cloud.VolumeAPIVersion
+		}
+	}
+
+	for key, value := range cloud.Extra {
+		for _, suffix := range serviceKeySuffixes {
+			prefix, ok := strings.CutSuffix(key, "_"+suffix)
+			if !ok {
+				continue
+			}
+			official := serviceKeyPrefixes(strings.ReplaceAll(prefix, "_", "-"))[0]
+			if official != prefix {
+				if _, ok := cloud.Extra[official+"_"+suffix]; !ok {
+					cloud.Extra[official+"_"+suffix] = value
+				}
+				delete(cloud.Extra, key)
+			}
+			break
+		}
+	}
+}
+
+// serviceKeyPrefixes returns the prefixes of the clouds.yaml keys of a
+// service type, starting with the one of its official name.
+func serviceKeyPrefixes(serviceType string) []string {
+	types := []string{serviceType}
+	if aliases, ok := gophercloud.ServiceTypeAliases[serviceType]; ok {
+		types = append(types, aliases...)
+	} else {
+		for t, aliases := range gophercloud.ServiceTypeAliases {
+			if slices.Contains(aliases, serviceType) {
+				types = append([]string{t}, aliases...)
+				break
+			}
+		}
+	}
+
+	prefixes := make([]string, 0, len(types))
+	for _, t := range types {
+		prefix := strings.ReplaceAll(t, "-", "_")
+		if !slices.Contains(prefixes, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
@@ -9,7 +9,14 @@ type Clouds struct {
 	Clouds map[string]Cloud `yaml:"clouds" json:"clouds"`
 }
 
-// Cloud represents an entry in a clouds.yaml/public-clouds.yaml/secure.yaml file.
+// PublicClouds represents a collection of vendor profiles in a
+// clouds-public.yaml file. A cloud entry of clouds.yaml uses a profile by
+// setting its name in the Profile field.
+type PublicClouds struct {
+	PublicClouds map[string]Cloud `yaml:"public-clouds" json:"public-clouds"`
+}
+
+// Cloud represents an entry in a clouds.yaml/clouds-public.yaml/secure.yaml file.
 type Cloud struct {
 	Cloud      string    `yaml:"cloud,omitempty" json:"cloud,omitempty"`
 	Profile    string    `yaml:"profile,omitempty" json:"profile,omitempty"`
@@ -42,6 +49,11 @@ type Cloud struct {
 	// ClientKeyFile a path to a client key to use as part of the SSL
 	// transaction.
 	ClientKeyFile string `yaml:"key,omitempty" json:"key,omitempty"`
+
+	// Extra holds the keys of the cloud entry that have no dedicated field,
+	// such as the per-service settings read by ServiceConfig. It is not
+	// encoded in JSON.
+	Extra map[string]any `yaml:",inline" json:"-"`
 }
 
 // AuthInfo represents the auth section of a cloud entry or
@@ -126,6 +138,47 @@ type AuthInfo struct {
 	// TrustID is the ID of the trust to use as a trustee.
 	TrustID string `yaml:"trust_id,omitempty" json:"trust_id,omitempty"`
 
+	// IdentityProvider is the ID of the identity provider in Keystone, used
+	// with the OpenID Connect auth types.
+	IdentityProvider string `yaml:"identity_provider,omitempty" json:"identity_provider,omitempty"`
+
+	// Protocol is the ID of the federation protocol in Keystone, used with the
+	// OpenID Connect auth types.
+	Protocol string `yaml:"protocol,omitempty" json:"protocol,omitempty"`
+
+	// ClientID is the OpenID Connect client ID.
+	ClientID string `yaml:"client_id,omitempty" json:"client_id,omitempty"`
+
+	// ClientSecret is the OpenID Connect client secret.
+	ClientSecret string `yaml:"client_secret,omitempty" json:"client_secret,omitempty"`
+
+	// DiscoveryEndpoint is the URL of the OpenID Connect discovery document of
+	// the identity provider.
+	DiscoveryEndpoint string `yaml:"discovery_endpoint,omitempty" json:"discovery_endpoint,omitempty"`
+
+	// AccessTokenEndpoint is the URL of the token endpoint of the identity
+	// provider.
+	AccessTokenEndpoint string `yaml:"access_token_endpoint,omitempty" json:"access_token_endpoint,omitempty"`
+
+	// AccessTokenType is the field of the token endpoint response holding the
+	// token passed to Keystone, e.g. "access_token" or "id_token".
+	AccessTokenType string `yaml:"access_token_type,omitempty" json:"access_token_type,omitempty"`
+
+	// OpenIDScope is the space-separated list of OpenID Connect scopes
+	// requested from the identity provider.
+	OpenIDScope string `yaml:"openid_scope,omitempty" json:"openid_scope,omitempty"`
+
+	// AccessToken is an OpenID Connect access token, used with the
+	// v3oidcaccesstoken auth type.
+	AccessToken string `yaml:"access_token,omitempty" json:"access_token,omitempty"`
+
+	// Code is an OpenID Connect authorization code, used with the
+	// v3oidcauthcode auth type.
+	Code string `yaml:"code,omitempty" json:"code,omitempty"`
+
+	// RedirectURI is the redirect URI the authorization code was issued for.
+	RedirectURI string `yaml:"redirect_uri,omitempty" json:"redirect_uri,omitempty"`
+
 	// AllowReauth should be set to true if you grant permission for Gophercloud to
 	// cache your credentials in memory, and to allow Gophercloud to attempt to
 	// re-authenticate automatically if/when your token expires.  If you set it to
@@ -203,4 +256,17 @@ const (
 
 	// AuthV3ApplicationCredential defines version 3 of the application credential
 	AuthV3ApplicationCredential AuthType = "v3applicationcredential"
+
+	// AuthV3OIDCPassword defines OpenID Connect authentication with the
+	// password grant
+	AuthV3OIDCPassword AuthType = "v3oidcpassword"
+	// AuthV3OIDCClientCredentials defines OpenID Connect authentication with
+	// the client credentials grant
+	AuthV3OIDCClientCredentials AuthType = "v3oidcclientcredentials"
+	// AuthV3OIDCAccessToken defines OpenID Connect authentication with an
+	// access token
+	AuthV3OIDCAccessToken AuthType = "v3oidcaccesstoken"
+	// AuthV3OIDCAuthCode defines OpenID Connect authentication with the
+	// authorization code grant
+	AuthV3OIDCAuthCode AuthType = "v3oidcauthcode"
 )
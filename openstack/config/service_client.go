@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/config/clouds"
+)
+
+type serviceClientFunc func(*gophercloud.ProviderClient, gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error)
+
+// serviceClientVersion describes how a ServiceClient is created for a major
+// API version of a service.
+type serviceClientVersion struct {
+	// newClient creates the ServiceClient from the service catalog.
+	newClient serviceClientFunc
+
+	// endpointVersion is the version segment of the path which newClient
+	// appends to the endpoint of the catalog to build the Endpoint, if any.
+	endpointVersion string
+
+	// resourceVersion is the version segment of the path which newClient
+	// appends to the endpoint of the catalog to build the ResourceBase, if
+	// any.
+	resourceVersion string
+}
+
+// serviceClientVersions maps the supported service types to the way a
+// ServiceClient is created for each of their major API versions.
+var serviceClientVersions = map[string]map[string]serviceClientVersion{
+	"application-container":               {"1": {newClient: openstack.NewContainerV1}},
+	"baremetal":                           {"1": {newClient: openstack.NewBareMetalV1, resourceVersion: "v1"}},
+	"baremetal-introspection":             {"1": {newClient: openstack.NewBareMetalIntrospectionV1}},
+	"block-storage":                       {"2": {newClient: openstack.NewBlockStorageV2}, "3": {newClient: openstack.NewBlockStorageV3}},
+	"compute":                             {"2": {newClient: openstack.NewComputeV2}},
+	"container-infrastructure-management": {"1": {newClient: openstack.NewContainerInfraV1}},
+	"database":                            {"1": {newClient: openstack.NewDBV1}},
+	"dns":                                 {"2": {newClient: openstack.NewDNSV2, resourceVersion: "v2"}},
+	"identity":                            {"2": {newClient: openstack.NewIdentityV2, endpointVersion: "v2.0"}, "3": {newClient: openstack.NewIdentityV3, endpointVersion: "v3"}},
+	"image":                               {"2": {newClient: openstack.NewImageV2, resourceVersion: "v2"}},
+	"key-manager":                         {"1": {newClient: openstack.NewKeyManagerV1, resourceVersion: "v1"}},
+	"load-balancer":                       {"2": {newClient: openstack.NewLoadBalancerV2, resourceVersion: "v2.0"}},
+	"network":                             {"2": {newClient: openstack.NewNetworkV2, resourceVersion: "v2.0"}},
+	"object-store":                        {"1": {newClient: openstack.NewObjectStorageV1}},
+	"orchestration":                       {"1": {newClient: openstack.NewOrchestrationV1}},
+	"placement":                           {"1": {newClient: openstack.NewPlacementV1}},
+	"shared-file-system":                  {"2": {newClient: openstack.NewSharedFileSystemV2}},
+	"workflow":                            {"2": {newClient: openstack.NewWorkflowV2}},
+}
+
+// NewServiceClient creates a ServiceClient for the given service type, using
+// the per-service settings of a cloud entry returned by clouds.ParseCloud:
+//
+//	cloud, err := clouds.ParseCloud(clouds.WithCloudName("openstack"))
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	computeClient, err := config.NewServiceClient(providerClient, cloud, "compute")
+//	if err != nil {
+//		panic(err)
+//	}
+//
+// When the cloud entry sets an endpoint override for the service, it is used
+// instead of the service catalog. It may include the version segment of the
+// API, as in https://network.example.com:9696/v2.0. Otherwise, the endpoint is
+// searched in the catalog with the region and interface set for the service.
+// The API version set for the service selects the client version, and
+// defaults to the latest supported version.
+func NewServiceClient(client *gophercloud.ProviderClient, cloud clouds.Cloud, serviceType string) (*gophercloud.ServiceClient, error) {
+	clientType := serviceType
+	versions, ok := serviceClientVersions[clientType]
+	if !ok {
+		for t, aliases := range gophercloud.ServiceTypeAliases {
+			if slices.Contains(aliases, serviceType) {
+				clientType = t
+				versions, ok = serviceClientVersions[t]
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported service type %q", serviceType)
+	}
+
+	sc := cloud.ServiceConfig(serviceType)
+
+	version := slices.Max(slices.Collect(maps.Keys(versions)))
+	if sc.APIVersion != "" {
+		version, _, _ = strings.Cut(strings.TrimPrefix(sc.APIVersion, "v"), ".")
+	}
+	v, ok := versions[version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported API version %q for service type %q", sc.APIVersion, serviceType)
+	}
+
+	if sc.EndpointOverride == "" {
+		return v.newClient(client, sc.EndpointOpts())
+	}
+
+	// The version segment which the ServiceClient adds to the endpoint is
+	// removed from the endpoint override, if it already ends with it.
+	endpoint := gophercloud.NormalizeURL(sc.EndpointOverride)
+	serviceClient := &gophercloud.ServiceClient{
+		ProviderClient: client,
+		Endpoint:       endpoint,
+		Type:           clientType,
+	}
+	switch {
+	case v.endpointVersion != "":
+		serviceClient.Endpoint = strings.TrimSuffix(endpoint, v.endpointVersion+"/") + v.endpointVersion + "/"
+	case v.resourceVersion != "":
+		serviceClient.Endpoint = strings.TrimSuffix(endpoint, v.resourceVersion+"/")
+		serviceClient.ResourceBase = serviceClient.Endpoint + v.resourceVersion + "/"
+	}
+	return serviceClient, nil
+}
@@ -0,0 +1,2 @@
+// config unit tests
+package testing
@@ -0,0 +1,100 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/config"
+	"github.com/gophercloud/gophercloud/v2/openstack/config/clouds"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestNewServiceClientFromCatalog(t *testing.T) {
+	var searched gophercloud.EndpointOpts
+	provider := &gophercloud.ProviderClient{
+		EndpointLocator: func(eo gophercloud.EndpointOpts) (string, error) {
+			searched = eo
+			return "https://network.example.com/", nil
+		},
+	}
+	cloud := clouds.Cloud{
+		RegionName: "RegionOne",
+		Extra: map[string]any{
+			"network_interface":   "internal",
+			"network_region_name": "RegionTwo",
+		},
+	}
+
+	client, err := config.NewServiceClient(provider, cloud, "network")
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "RegionTwo", searched.Region)
+	th.AssertEquals(t, gophercloud.AvailabilityInternal, searched.Availability)
+	th.AssertEquals(t, "https://network.example.com/", client.Endpoint)
+	th.AssertEquals(t, "https://network.example.com/v2.0/", client.ResourceBase)
+	th.AssertEquals(t, provider, client.ProviderClient)
+}
+
+func TestNewServiceClientEndpointOverride(t *testing.T) {
+	provider := &gophercloud.ProviderClient{
+		EndpointLocator: func(eo gophercloud.EndpointOpts) (string, error) {
+			t.Errorf("unexpected catalog search for %q", eo.Type)
+			return "", nil
+		},
+	}
+	cloud := clouds.Cloud{
+		Extra: map[string]any{
+			"image_endpoint_override":    "https://image.example.com",
+			"block_storage_api_version":  "2",
+			"volume_endpoint_override":   "https://volume.example.com/v2/project",
+			"identity_endpoint_override": "https://identity.example.com/",
+		},
+	}
+
+	image, err := config.NewServiceClient(provider, cloud, "image")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://image.example.com/", image.Endpoint)
+	th.AssertEquals(t, "https://image.example.com/v2/", image.ResourceBase)
+	th.AssertEquals(t, provider, image.ProviderClient)
+
+	volume, err := config.NewServiceClient(provider, cloud, "block-storage")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://volume.example.com/v2/project/", volume.Endpoint)
+	th.AssertEquals(t, "block-storage", volume.Type)
+
+	identity, err := config.NewServiceClient(provider, cloud, "identity")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://identity.example.com/v3/", identity.Endpoint)
+}
+
+func TestNewServiceClientVersionedEndpointOverride(t *testing.T) {
+	provider := &gophercloud.ProviderClient{}
+	cloud := clouds.Cloud{
+		Extra: map[string]any{
+			"network_api_version":        "2.0",
+			"network_endpoint_override":  "https://network.example.com:9696/v2.0",
+			"identity_endpoint_override": "https://identity.example.com/v3/",
+		},
+	}
+
+	network, err := config.NewServiceClient(provider, cloud, "network")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://network.example.com:9696/", network.Endpoint)
+	th.AssertEquals(t, "https://network.example.com:9696/v2.0/", network.ResourceBase)
+	th.AssertEquals(t, "network", network.Type)
+
+	identity, err := config.NewServiceClient(provider, cloud, "identity")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://identity.example.com/v3/", identity.Endpoint)
+}
+
+func TestNewServiceClientUnsupported(t *testing.T) {
+	provider := &gophercloud.ProviderClient{}
+
+	_, err := config.NewServiceClient(provider, clouds.Cloud{}, "unknown")
+	th.AssertErr(t, err)
+
+	cloud := clouds.Cloud{Extra: map[string]any{"compute_api_version": "3"}}
+	_, err = config.NewServiceClient(provider, cloud, "compute")
+	th.AssertErr(t, err)
+}
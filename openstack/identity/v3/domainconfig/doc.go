@@ -0,0 +1,61 @@
+/*
+Package domainconfig manages domain-specific configuration in the OpenStack
+Identity Service, such as per-domain LDAP backends.
+
+Example to Create a Domain Configuration
+
+	createOpts := domainconfig.CreateOpts{
+		"identity": {
+			"driver": "ldap",
+		},
+		"ldap": {
+			"url":          "ldap://ldap.example.com",
+			"user_tree_dn": "ou=Users,dc=example,dc=com",
+		},
+	}
+
+	config, err := domainconfig.Create(context.TODO(), identityClient, domainID, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get a Domain Configuration
+
+	config, err := domainconfig.Get(context.TODO(), identityClient, domainID).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update a Single Configuration Group
+
+	updateOpts := domainconfig.UpdateGroupOpts{
+		"url": "ldap://ldap2.example.com",
+	}
+
+	config, err := domainconfig.UpdateGroup(context.TODO(), identityClient, domainID, "ldap", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get a Single Configuration Option
+
+	url, err := domainconfig.GetOption(context.TODO(), identityClient, domainID, "ldap", "url").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Get the Default Configuration
+
+	defaults, err := domainconfig.GetDefault(context.TODO(), identityClient).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete a Domain Configuration
+
+	err := domainconfig.Delete(context.TODO(), identityClient, domainID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package domainconfig
@@ -0,0 +1,178 @@
+package domainconfig
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// Get retrieves the full domain-specific configuration of a domain.
+func Get(ctx context.Context, client *gophercloud.ServiceClient, domainID string) (r GetResult) {
+	resp, err := client.Get(ctx, configURL(client, domainID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to
+// the Create request.
+type CreateOptsBuilder interface {
+	ToDomainConfigCreateMap() (map[string]any, error)
+}
+
+// CreateOpts is the domain-specific configuration to create, keyed by group
+// and then by option, e.g. {"identity": {"driver": "ldap"}}.
+type CreateOpts map[string]map[string]any
+
+// ToDomainConfigCreateMap formats a CreateOpts into a create request.
+func (opts CreateOpts) ToDomainConfigCreateMap() (map[string]any, error) {
+	return map[string]any{"config": map[string]map[string]any(opts)}, nil
+}
+
+// Create creates the domain-specific configuration of a domain.
+func Create(ctx context.Context, client *gophercloud.ServiceClient, domainID string, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToDomainConfigCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(ctx, configURL(client, domainID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to
+// the Update request.
+type UpdateOptsBuilder interface {
+	ToDomainConfigUpdateMap() (map[string]any, error)
+}
+
+// UpdateOpts is the domain-specific configuration to merge into the existing
+// one, keyed by group and then by option.
+type UpdateOpts map[string]map[string]any
+
+// ToDomainConfigUpdateMap formats an UpdateOpts into an update request.
+func (opts UpdateOpts) ToDomainConfigUpdateMap() (map[string]any, error) {
+	return map[string]any{"config": map[string]map[string]any(opts)}, nil
+}
+
+// Update modifies the domain-specific configuration of a domain.
+func Update(ctx context.Context, client *gophercloud.ServiceClient, domainID string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToDomainConfigUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(ctx, configURL(client, domainID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes the domain-specific configuration of a domain.
+func Delete(ctx context.Context, client *gophercloud.ServiceClient, domainID string) (r DeleteResult) {
+	resp, err := client.Delete(ctx, configURL(client, domainID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetGroup retrieves a single group of the domain-specific configuration of
+// a domain.
+func GetGroup(ctx context.Context, client *gophercloud.ServiceClient, domainID, group string) (r GetGroupResult) {
+	resp, err := client.Get(ctx, groupURL(client, domainID, group), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	r.group = group
+	return
+}
+
+// UpdateGroupOptsBuilder allows extensions to add additional parameters to
+// the UpdateGroup request.
+type UpdateGroupOptsBuilder interface {
+	ToDomainConfigGroupUpdateMap(group string) (map[string]any, error)
+}
+
+// UpdateGroupOpts is the set of options to merge into a configuration group.
+type UpdateGroupOpts map[string]any
+
+// ToDomainConfigGroupUpdateMap formats an UpdateGroupOpts into an update
+// request.
+func (opts UpdateGroupOpts) ToDomainConfigGroupUpdateMap(group string) (map[string]any, error) {
+	return map[string]any{"config": map[string]any{group: map[string]any(opts)}}, nil
+}
+
+// UpdateGroup modifies a single group of the domain-specific configuration of
+// a domain. The full configuration is returned.
+func UpdateGroup(ctx context.Context, client *gophercloud.ServiceClient, domainID, group string, opts UpdateGroupOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToDomainConfigGroupUpdateMap(group)
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(ctx, groupURL(client, domainID, group), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteGroup deletes a single group of the domain-specific configuration of
+// a domain.
+func DeleteGroup(ctx context.Context, client *gophercloud.ServiceClient, domainID, group string) (r DeleteResult) {
+	resp, err := client.Delete(ctx, groupURL(client, domainID, group), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetOption retrieves a single option of the domain-specific configuration of
+// a domain.
+func GetOption(ctx context.Context, client *gophercloud.ServiceClient, domainID, group, option string) (r GetOptionResult) {
+	resp, err := client.Get(ctx, optionURL(client, domainID, group, option), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	r.option = option
+	return
+}
+
+// UpdateOption sets a single option of the domain-specific configuration of
+// a domain. The full configuration is returned.
+func UpdateOption(ctx context.Context, client *gophercloud.ServiceClient, domainID, group, option string, value any) (r UpdateResult) {
+	b := map[string]any{"config": map[string]any{option: value}}
+	resp, err := client.Patch(ctx, optionURL(client, domainID, group, option), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteOption deletes a single option of the domain-specific configuration of
+// a domain.
+func DeleteOption(ctx context.Context, client *gophercloud.ServiceClient, domainID, group, option string) (r DeleteResult) {
+	resp, err := client.Delete(ctx, optionURL(client, domainID, group, option), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetDefault retrieves the default configuration that applies to all domains
+// without a domain-specific override.
+func GetDefault(ctx context.Context, client *gophercloud.ServiceClient) (r GetResult) {
+	resp, err := client.Get(ctx, defaultURL(client), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetGroupDefault retrieves the default configuration of a single group.
+func GetGroupDefault(ctx context.Context, client *gophercloud.ServiceClient, group string) (r GetGroupResult) {
+	resp, err := client.Get(ctx, groupDefaultURL(client, group), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	r.group = group
+	return
+}
+
+// GetOptionDefault retrieves the default value of a single option.
+func GetOptionDefault(ctx context.Context, client *gophercloud.ServiceClient, group, option string) (r GetOptionResult) {
+	resp, err := client.Get(ctx, optionDefaultURL(client, group, option), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	r.option = option
+	return
+}
@@ -0,0 +1,78 @@
+package domainconfig
+
+import "github.com/gophercloud/gophercloud/v2"
+
+// Config is a domain-specific configuration, keyed by group and then by
+// option, e.g. {"ldap": {"url": "ldap://localhost"}}.
+type Config map[string]map[string]any
+
+type configResult struct {
+	gophercloud.Result
+}
+
+// Extract interprets any configResult as a Config.
+func (r configResult) Extract() (Config, error) {
+	var s struct {
+		Config Config `json:"config"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Config, err
+}
+
+// GetResult is the response from a Get or GetDefault operation. Call its
+// Extract method to interpret it as a Config.
+type GetResult struct {
+	configResult
+}
+
+// CreateResult is the response from a Create operation. Call its Extract
+// method to interpret it as a Config.
+type CreateResult struct {
+	configResult
+}
+
+// UpdateResult is the response from an Update, UpdateGroup or UpdateOption
+// operation. Call its Extract method to interpret it as a Config.
+type UpdateResult struct {
+	configResult
+}
+
+// DeleteResult is the response from a Delete, DeleteGroup or DeleteOption
+// operation. Call its ExtractErr to determine if the request succeeded or
+// failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// GetGroupResult is the response from a GetGroup or GetGroupDefault
+// operation. Call its Extract method to interpret it as the options of the
+// group.
+type GetGroupResult struct {
+	gophercloud.Result
+	group string
+}
+
+// Extract interprets a GetGroupResult as the options of the requested group.
+func (r GetGroupResult) Extract() (map[string]any, error) {
+	var s struct {
+		Config Config `json:"config"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Config[r.group], err
+}
+
+// GetOptionResult is the response from a GetOption or GetOptionDefault
+// operation. Call its Extract method to interpret it as the option value.
+type GetOptionResult struct {
+	gophercloud.Result
+	option string
+}
+
+// Extract interprets a GetOptionResult as the value of the requested option.
+func (r GetOptionResult) Extract() (any, error) {
+	var s struct {
+		Config map[string]any `json:"config"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Config[r.option], err
+}
@@ -0,0 +1,178 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/domainconfig"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+// ConfigOutput provides a full domain configuration.
+const ConfigOutput = `
+{
+    "config": {
+        "identity": {
+            "driver": "ldap"
+        },
+        "ldap": {
+            "url": "ldap://ldap.example.com",
+            "user_tree_dn": "ou=Users,dc=example,dc=com"
+        }
+    }
+}
+`
+
+// CreateRequest provides the input to a Create request.
+const CreateRequest = `
+{
+    "config": {
+        "identity": {
+            "driver": "ldap"
+        },
+        "ldap": {
+            "url": "ldap://ldap.example.com",
+            "user_tree_dn": "ou=Users,dc=example,dc=com"
+        }
+    }
+}
+`
+
+// UpdateRequest provides the input to an Update request.
+const UpdateRequest = `
+{
+    "config": {
+        "ldap": {
+            "url": "ldap://ldap2.example.com"
+        }
+    }
+}
+`
+
+// UpdateOutput provides the full configuration after an update.
+const UpdateOutput = `
+{
+    "config": {
+        "identity": {
+            "driver": "ldap"
+        },
+        "ldap": {
+            "url": "ldap://ldap2.example.com",
+            "user_tree_dn": "ou=Users,dc=example,dc=com"
+        }
+    }
+}
+`
+
+// UpdateOptionRequest provides the input to an UpdateOption request.
+const UpdateOptionRequest = `
+{
+    "config": {
+        "url": "ldap://ldap2.example.com"
+    }
+}
+`
+
+// GroupOutput provides a single configuration group.
+const GroupOutput = `
+{
+    "config": {
+        "ldap": {
+            "url": "ldap://ldap.example.com",
+            "user_tree_dn": "ou=Users,dc=example,dc=com"
+        }
+    }
+}
+`
+
+// OptionOutput provides a single configuration option.
+const OptionOutput = `
+{
+    "config": {
+        "url": "ldap://ldap.example.com"
+    }
+}
+`
+
+// ExpectedConfig is the domain configuration in ConfigOutput.
+var ExpectedConfig = domainconfig.Config{
+	"identity": {
+		"driver": "ldap",
+	},
+	"ldap": {
+		"url":          "ldap://ldap.example.com",
+		"user_tree_dn": "ou=Users,dc=example,dc=com",
+	},
+}
+
+// ExpectedUpdatedConfig is the domain configuration in UpdateOutput.
+var ExpectedUpdatedConfig = domainconfig.Config{
+	"identity": {
+		"driver": "ldap",
+	},
+	"ldap": {
+		"url":          "ldap://ldap2.example.com",
+		"user_tree_dn": "ou=Users,dc=example,dc=com",
+	},
+}
+
+// ExpectedGroup is the configuration group in GroupOutput.
+var ExpectedGroup = map[string]any{
+	"url":          "ldap://ldap.example.com",
+	"user_tree_dn": "ou=Users,dc=example,dc=com",
+}
+
+// HandleGetSuccessfully creates an HTTP handler at the given path on the test
+// handler mux that responds with the given output.
+func HandleGetSuccessfully(t *testing.T, path, output string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, output)
+	})
+}
+
+// HandleCreateSuccessfully creates an HTTP handler at `/domains/d1/config` on
+// the test handler mux that tests domain configuration creation.
+func HandleCreateSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/domains/d1/config", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PUT")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, ConfigOutput)
+	})
+}
+
+// HandleUpdateSuccessfully creates an HTTP handler at the given path on the
+// test handler mux that tests domain configuration updates.
+func HandleUpdateSuccessfully(t *testing.T, path, request string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, request)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, UpdateOutput)
+	})
+}
+
+// HandleDeleteSuccessfully creates an HTTP handler at the given path on the
+// test handler mux that tests domain configuration deletion.
+func HandleDeleteSuccessfully(t *testing.T, path string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,147 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/domainconfig"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+func TestGetConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetSuccessfully(t, "/domains/d1/config", ConfigOutput)
+
+	actual, err := domainconfig.Get(context.TODO(), client.ServiceClient(), "d1").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedConfig, actual)
+}
+
+func TestCreateConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateSuccessfully(t)
+
+	createOpts := domainconfig.CreateOpts{
+		"identity": {
+			"driver": "ldap",
+		},
+		"ldap": {
+			"url":          "ldap://ldap.example.com",
+			"user_tree_dn": "ou=Users,dc=example,dc=com",
+		},
+	}
+
+	actual, err := domainconfig.Create(context.TODO(), client.ServiceClient(), "d1", createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedConfig, actual)
+}
+
+func TestUpdateConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateSuccessfully(t, "/domains/d1/config", UpdateRequest)
+
+	updateOpts := domainconfig.UpdateOpts{
+		"ldap": {
+			"url": "ldap://ldap2.example.com",
+		},
+	}
+
+	actual, err := domainconfig.Update(context.TODO(), client.ServiceClient(), "d1", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedUpdatedConfig, actual)
+}
+
+func TestDeleteConfig(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteSuccessfully(t, "/domains/d1/config")
+
+	err := domainconfig.Delete(context.TODO(), client.ServiceClient(), "d1").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestGetGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetSuccessfully(t, "/domains/d1/config/ldap", GroupOutput)
+
+	actual, err := domainconfig.GetGroup(context.TODO(), client.ServiceClient(), "d1", "ldap").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedGroup, actual)
+}
+
+func TestUpdateGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateSuccessfully(t, "/domains/d1/config/ldap", UpdateRequest)
+
+	updateOpts := domainconfig.UpdateGroupOpts{
+		"url": "ldap://ldap2.example.com",
+	}
+
+	actual, err := domainconfig.UpdateGroup(context.TODO(), client.ServiceClient(), "d1", "ldap", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedUpdatedConfig, actual)
+}
+
+func TestDeleteGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteSuccessfully(t, "/domains/d1/config/ldap")
+
+	err := domainconfig.DeleteGroup(context.TODO(), client.ServiceClient(), "d1", "ldap").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestGetOption(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetSuccessfully(t, "/domains/d1/config/ldap/url", OptionOutput)
+
+	actual, err := domainconfig.GetOption(context.TODO(), client.ServiceClient(), "d1", "ldap", "url").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "ldap://ldap.example.com", actual)
+}
+
+func TestUpdateOption(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateSuccessfully(t, "/domains/d1/config/ldap/url", UpdateOptionRequest)
+
+	actual, err := domainconfig.UpdateOption(context.TODO(), client.ServiceClient(), "d1", "ldap", "url", "ldap://ldap2.example.com").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedUpdatedConfig, actual)
+}
+
+func TestDeleteOption(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteSuccessfully(t, "/domains/d1/config/ldap/url")
+
+	err := domainconfig.DeleteOption(context.TODO(), client.ServiceClient(), "d1", "ldap", "url").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestGetDefaults(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetSuccessfully(t, "/domains/config/default", ConfigOutput)
+	HandleGetSuccessfully(t, "/domains/config/ldap/default", GroupOutput)
+	HandleGetSuccessfully(t, "/domains/config/ldap/url/default", OptionOutput)
+
+	config, err := domainconfig.GetDefault(context.TODO(), client.ServiceClient()).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedConfig, config)
+
+	group, err := domainconfig.GetGroupDefault(context.TODO(), client.ServiceClient(), "ldap").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedGroup, group)
+
+	option, err := domainconfig.GetOptionDefault(context.TODO(), client.ServiceClient(), "ldap", "url").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "ldap://ldap.example.com", option)
+}
@@ -0,0 +1,33 @@
+package domainconfig
+
+import "github.com/gophercloud/gophercloud/v2"
+
+const (
+	domainsPath = "domains"
+	configPath  = "config"
+	defaultPath = "default"
+)
+
+func configURL(client *gophercloud.ServiceClient, domainID string) string {
+	return client.ServiceURL(domainsPath, domainID, configPath)
+}
+
+func groupURL(client *gophercloud.ServiceClient, domainID, group string) string {
+	return client.ServiceURL(domainsPath, domainID, configPath, group)
+}
+
+func optionURL(client *gophercloud.ServiceClient, domainID, group, option string) string {
+	return client.ServiceURL(domainsPath, domainID, configPath, group, option)
+}
+
+func defaultURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(domainsPath, configPath, defaultPath)
+}
+
+func groupDefaultURL(client *gophercloud.ServiceClient, group string) string {
+	return client.ServiceURL(domainsPath, configPath, group, defaultPath)
+}
+
+func optionDefaultURL(client *gophercloud.ServiceClient, group, option string) string {
+	return client.ServiceURL(domainsPath, configPath, group, option, defaultPath)
+}
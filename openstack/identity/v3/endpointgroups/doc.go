@@ -0,0 +1,82 @@
+/*
+Package endpointgroups manages endpoint groups, and their associations with
+projects, through the OS-EP-FILTER API of the OpenStack Identity Service.
+
+An endpoint group selects endpoints with filters on their interface, service
+and region. Associating it with a project makes its endpoints part of the
+catalog of the project, without associating the endpoints one by one.
+
+For more information, see:
+https://docs.openstack.org/api-ref/identity/v3-ext/#endpoint-filter
+
+Example to List Endpoint Groups
+
+	allPages, err := endpointgroups.List(identityClient, nil).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allEndpointGroups, err := endpointgroups.ExtractEndpointGroups(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, endpointGroup := range allEndpointGroups {
+		fmt.Printf("%+v\n", endpointGroup)
+	}
+
+Example to Create an Endpoint Group
+
+	createOpts := endpointgroups.CreateOpts{
+		Name:        "public-east",
+		Description: "Public endpoints of the east region",
+		Filters: endpointgroups.Filters{
+			Availability: gophercloud.AvailabilityPublic,
+			RegionID:     "east",
+		},
+	}
+
+	endpointGroup, err := endpointgroups.Create(context.TODO(), identityClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Update an Endpoint Group
+
+	description := "Public endpoints of the east regions"
+	updateOpts := endpointgroups.UpdateOpts{
+		Description: &description,
+	}
+
+	endpointGroup, err := endpointgroups.Update(context.TODO(), identityClient, endpointGroupID, updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Delete an Endpoint Group
+
+	err := endpointgroups.Delete(context.TODO(), identityClient, endpointGroupID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Associate an Endpoint Group with a Project
+
+	err := endpointgroups.AddProject(context.TODO(), identityClient, endpointGroupID, projectID).ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Endpoints of an Endpoint Group
+
+	allPages, err := endpointgroups.ListEndpoints(identityClient, endpointGroupID).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allEndpoints, err := endpoints.ExtractEndpoints(allPages)
+	if err != nil {
+		panic(err)
+	}
+*/
+package endpointgroups
@@ -0,0 +1,189 @@
+package endpointgroups
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/endpoints"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to
+// the List request.
+type ListOptsBuilder interface {
+	ToEndpointGroupListQuery() (string, error)
+}
+
+// ListOpts provides options to filter the List results.
+type ListOpts struct {
+	// Name filters the response by endpoint group name.
+	Name string `q:"name"`
+}
+
+// ToEndpointGroupListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToEndpointGroupListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// List enumerates the endpoint groups.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToEndpointGroupListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return EndpointGroupPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// Get retrieves details on a single endpoint group, by ID.
+func Get(ctx context.Context, client *gophercloud.ServiceClient, id string) (r GetResult) {
+	resp, err := client.Get(ctx, getURL(client, id), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to
+// the Create request.
+type CreateOptsBuilder interface {
+	ToEndpointGroupCreateMap() (map[string]any, error)
+}
+
+// CreateOpts provides options used to create an endpoint group.
+type CreateOpts struct {
+	// Name is the name of the endpoint group.
+	Name string `json:"name" required:"true"`
+
+	// Description is a description of the endpoint group.
+	Description string `json:"description,omitempty"`
+
+	// Filters select the endpoints of the group.
+	Filters Filters `json:"filters" required:"true"`
+}
+
+// ToEndpointGroupCreateMap formats a CreateOpts into a create request.
+func (opts CreateOpts) ToEndpointGroupCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "endpoint_group")
+}
+
+// Create creates a new endpoint group.
+func Create(ctx context.Context, client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToEndpointGroupCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Post(ctx, createURL(client), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to
+// the Update request.
+type UpdateOptsBuilder interface {
+	ToEndpointGroupUpdateMap() (map[string]any, error)
+}
+
+// UpdateOpts provides options for updating an endpoint group.
+type UpdateOpts struct {
+	// Name is the name of the endpoint group.
+	Name string `json:"name,omitempty"`
+
+	// Description is a description of the endpoint group.
+	Description *string `json:"description,omitempty"`
+
+	// Filters select the endpoints of the group. They replace the existing
+	// filters.
+	Filters *Filters `json:"filters,omitempty"`
+}
+
+// ToEndpointGroupUpdateMap formats an UpdateOpts into an update request.
+func (opts UpdateOpts) ToEndpointGroupUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "endpoint_group")
+}
+
+// Update updates an existing endpoint group.
+func Update(ctx context.Context, client *gophercloud.ServiceClient, groupID string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToEndpointGroupUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(ctx, updateURL(client, groupID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// Delete deletes an endpoint group.
+func Delete(ctx context.Context, client *gophercloud.ServiceClient, groupID string) (r DeleteResult) {
+	resp, err := client.Delete(ctx, deleteURL(client, groupID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// AddProject associates an endpoint group with a project, which makes the
+// endpoints of the group part of the catalog of the project.
+func AddProject(ctx context.Context, client *gophercloud.ServiceClient, groupID, projectID string) (r AddProjectResult) {
+	resp, err := client.Put(ctx, projectURL(client, groupID, projectID), nil, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// IsProjectAssociated checks whether an endpoint group is associated with a
+// project.
+func IsProjectAssociated(ctx context.Context, client *gophercloud.ServiceClient, groupID, projectID string) (r IsProjectAssociatedResult) {
+	resp, err := client.Head(ctx, projectURL(client, groupID, projectID), &gophercloud.RequestOpts{
+		OkCodes: []int{200, 204, 404},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	if r.Err == nil {
+		r.isAssociated = resp.StatusCode != 404
+	}
+	return
+}
+
+// RemoveProject removes the association of an endpoint group with a project.
+func RemoveProject(ctx context.Context, client *gophercloud.ServiceClient, groupID, projectID string) (r RemoveProjectResult) {
+	resp, err := client.Delete(ctx, projectURL(client, groupID, projectID), &gophercloud.RequestOpts{
+		OkCodes: []int{204},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListProjects enumerates the projects associated with an endpoint group.
+func ListProjects(client *gophercloud.ServiceClient, groupID string) pagination.Pager {
+	url := listProjectsURL(client, groupID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return projects.ProjectPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListEndpoints enumerates the endpoints matching the filters of an endpoint
+// group.
+func ListEndpoints(client *gophercloud.ServiceClient, groupID string) pagination.Pager {
+	url := listEndpointsURL(client, groupID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return endpoints.EndpointPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListForProject enumerates the endpoint groups associated with a project.
+func ListForProject(client *gophercloud.ServiceClient, projectID string) pagination.Pager {
+	url := listForProjectURL(client, projectID)
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return EndpointGroupPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
@@ -0,0 +1,2 @@
+// endpointgroups unit tests
+package testing
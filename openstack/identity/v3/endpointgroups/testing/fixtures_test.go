@@ -0,0 +1,340 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/endpointgroups"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+// ListOutput provides a single page of EndpointGroup results.
+const ListOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups"
+    },
+    "endpoint_groups": [
+        {
+            "id": "ac4861",
+            "name": "public-east",
+            "description": "Public endpoints of the east region",
+            "filters": {
+                "interface": "public",
+                "region_id": "east"
+            },
+            "links": {
+                "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861"
+            }
+        },
+        {
+            "id": "3de9f3",
+            "name": "compute",
+            "description": "",
+            "filters": {
+                "service_id": "9242e0"
+            },
+            "links": {
+                "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/3de9f3"
+            }
+        }
+    ]
+}
+`
+
+// GetOutput provides a Get result.
+const GetOutput = `
+{
+    "endpoint_group": {
+        "id": "ac4861",
+        "name": "public-east",
+        "description": "Public endpoints of the east region",
+        "filters": {
+            "interface": "public",
+            "region_id": "east"
+        },
+        "links": {
+            "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861"
+        }
+    }
+}
+`
+
+// CreateRequest provides the input to a Create request.
+const CreateRequest = `
+{
+    "endpoint_group": {
+        "name": "public-east",
+        "description": "Public endpoints of the east region",
+        "filters": {
+            "interface": "public",
+            "region_id": "east"
+        }
+    }
+}
+`
+
+// UpdateRequest provides the input to an Update request.
+const UpdateRequest = `
+{
+    "endpoint_group": {
+        "description": "Public endpoints of the east regions",
+        "filters": {
+            "interface": "public"
+        }
+    }
+}
+`
+
+// UpdateOutput provides an Update response.
+const UpdateOutput = `
+{
+    "endpoint_group": {
+        "id": "ac4861",
+        "name": "public-east",
+        "description": "Public endpoints of the east regions",
+        "filters": {
+            "interface": "public"
+        },
+        "links": {
+            "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861"
+        }
+    }
+}
+`
+
+// ListProjectsOutput provides the projects associated with an endpoint group.
+const ListProjectsOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861/projects"
+    },
+    "projects": [
+        {
+            "domain_id": "default",
+            "enabled": true,
+            "id": "263fd9",
+            "is_domain": false,
+            "name": "project",
+            "links": {
+                "self": "http://example.com/identity/v3/projects/263fd9"
+            }
+        }
+    ]
+}
+`
+
+// ListEndpointsOutput provides the endpoints of an endpoint group.
+const ListEndpointsOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861/endpoints"
+    },
+    "endpoints": [
+        {
+            "id": "6fedc0",
+            "interface": "public",
+            "region": "east",
+            "region_id": "east",
+            "service_id": "1b501a",
+            "url": "https://compute.east.example.com/v2.1",
+            "enabled": true,
+            "links": {
+                "self": "http://example.com/identity/v3/endpoints/6fedc0"
+            }
+        }
+    ]
+}
+`
+
+// FirstEndpointGroup is the first endpoint group in the List request.
+var FirstEndpointGroup = endpointgroups.EndpointGroup{
+	ID:          "ac4861",
+	Name:        "public-east",
+	Description: "Public endpoints of the east region",
+	Filters: endpointgroups.Filters{
+		Availability: gophercloud.AvailabilityPublic,
+		RegionID:     "east",
+	},
+	Links: map[string]any{
+		"self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861",
+	},
+}
+
+// SecondEndpointGroup is the second endpoint group in the List request.
+var SecondEndpointGroup = endpointgroups.EndpointGroup{
+	ID:   "3de9f3",
+	Name: "compute",
+	Filters: endpointgroups.Filters{
+		ServiceID: "9242e0",
+	},
+	Links: map[string]any{
+		"self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/3de9f3",
+	},
+}
+
+// UpdatedEndpointGroup is the endpoint group updated by the Update request.
+var UpdatedEndpointGroup = endpointgroups.EndpointGroup{
+	ID:          "ac4861",
+	Name:        "public-east",
+	Description: "Public endpoints of the east regions",
+	Filters: endpointgroups.Filters{
+		Availability: gophercloud.AvailabilityPublic,
+	},
+	Links: map[string]any{
+		"self": "http://example.com/identity/v3/OS-EP-FILTER/endpoint_groups/ac4861",
+	},
+}
+
+// ExpectedEndpointGroupsSlice is the slice of endpoint groups expected to be
+// returned from ListOutput.
+var ExpectedEndpointGroupsSlice = []endpointgroups.EndpointGroup{FirstEndpointGroup, SecondEndpointGroup}
+
+// HandleListEndpointGroupsSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups` on the test handler mux that responds with
+// a list of two endpoint groups.
+func HandleListEndpointGroupsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListOutput)
+	})
+}
+
+// HandleGetEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups` on the test handler mux that responds with
+// a single endpoint group.
+func HandleGetEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, GetOutput)
+	})
+}
+
+// HandleCreateEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups` on the test handler mux that tests endpoint
+// group creation.
+func HandleCreateEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, CreateRequest)
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, GetOutput)
+	})
+}
+
+// HandleUpdateEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups` on the test handler mux that tests endpoint
+// group update.
+func HandleUpdateEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestJSONRequest(t, r, UpdateRequest)
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, UpdateOutput)
+	})
+}
+
+// HandleDeleteEndpointGroupSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups` on the test handler mux that tests endpoint
+// group deletion.
+func HandleDeleteEndpointGroupSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// HandleProjectAssociationSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups/ac4861/projects` on the test handler mux
+// that tests the association of endpoint groups with projects.
+func HandleProjectAssociationSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861/projects/263fd9", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		switch r.Method {
+		case "PUT", "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		case "HEAD":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861/projects/9fe1d3", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "HEAD")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+// HandleListProjectsSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups/ac4861/projects` on the test handler mux
+// that responds with the projects associated with an endpoint group.
+func HandleListProjectsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861/projects", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListProjectsOutput)
+	})
+}
+
+// HandleListEndpointsSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/endpoint_groups/ac4861/endpoints` on the test handler mux
+// that responds with the endpoints of an endpoint group.
+func HandleListEndpointsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/endpoint_groups/ac4861/endpoints", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListEndpointsOutput)
+	})
+}
+
+// HandleListForProjectSuccessfully creates an HTTP handler at
+// `/OS-EP-FILTER/projects/263fd9/endpoint_groups` on the test handler mux
+// that responds with the endpoint groups associated with a project.
+func HandleListForProjectSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-EP-FILTER/projects/263fd9/endpoint_groups", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListOutput)
+	})
+}
@@ -0,0 +1,160 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/endpointgroups"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/endpoints"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+func TestListEndpointGroups(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListEndpointGroupsSuccessfully(t)
+
+	count := 0
+	err := endpointgroups.List(client.ServiceClient(), nil).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := endpointgroups.ExtractEndpointGroups(page)
+		th.AssertNoErr(t, err)
+
+		th.CheckDeepEquals(t, ExpectedEndpointGroupsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestGetEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetEndpointGroupSuccessfully(t)
+
+	actual, err := endpointgroups.Get(context.TODO(), client.ServiceClient(), "ac4861").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FirstEndpointGroup, *actual)
+}
+
+func TestCreateEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateEndpointGroupSuccessfully(t)
+
+	createOpts := endpointgroups.CreateOpts{
+		Name:        "public-east",
+		Description: "Public endpoints of the east region",
+		Filters: endpointgroups.Filters{
+			Availability: gophercloud.AvailabilityPublic,
+			RegionID:     "east",
+		},
+	}
+
+	actual, err := endpointgroups.Create(context.TODO(), client.ServiceClient(), createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, FirstEndpointGroup, *actual)
+}
+
+func TestCreateEndpointGroupMissingName(t *testing.T) {
+	createOpts := endpointgroups.CreateOpts{
+		Filters: endpointgroups.Filters{
+			RegionID: "east",
+		},
+	}
+
+	res := endpointgroups.Create(context.TODO(), client.ServiceClient(), createOpts)
+	th.AssertErr(t, res.Err)
+}
+
+func TestUpdateEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateEndpointGroupSuccessfully(t)
+
+	description := "Public endpoints of the east regions"
+	updateOpts := endpointgroups.UpdateOpts{
+		Description: &description,
+		Filters: &endpointgroups.Filters{
+			Availability: gophercloud.AvailabilityPublic,
+		},
+	}
+
+	actual, err := endpointgroups.Update(context.TODO(), client.ServiceClient(), "ac4861", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, UpdatedEndpointGroup, *actual)
+}
+
+func TestDeleteEndpointGroup(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteEndpointGroupSuccessfully(t)
+
+	res := endpointgroups.Delete(context.TODO(), client.ServiceClient(), "ac4861")
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestProjectAssociation(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleProjectAssociationSuccessfully(t)
+
+	err := endpointgroups.AddProject(context.TODO(), client.ServiceClient(), "ac4861", "263fd9").ExtractErr()
+	th.AssertNoErr(t, err)
+
+	ok, err := endpointgroups.IsProjectAssociated(context.TODO(), client.ServiceClient(), "ac4861", "263fd9").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, ok)
+
+	ok, err = endpointgroups.IsProjectAssociated(context.TODO(), client.ServiceClient(), "ac4861", "9fe1d3").Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, false, ok)
+
+	err = endpointgroups.RemoveProject(context.TODO(), client.ServiceClient(), "ac4861", "263fd9").ExtractErr()
+	th.AssertNoErr(t, err)
+}
+
+func TestListProjects(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListProjectsSuccessfully(t)
+
+	allPages, err := endpointgroups.ListProjects(client.ServiceClient(), "ac4861").AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := projects.ExtractProjects(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual))
+	th.AssertEquals(t, "263fd9", actual[0].ID)
+}
+
+func TestListEndpoints(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListEndpointsSuccessfully(t)
+
+	allPages, err := endpointgroups.ListEndpoints(client.ServiceClient(), "ac4861").AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := endpoints.ExtractEndpoints(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual))
+	th.AssertEquals(t, "6fedc0", actual[0].ID)
+	th.AssertEquals(t, "https://compute.east.example.com/v2.1", actual[0].URL)
+}
+
+func TestListForProject(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListForProjectSuccessfully(t)
+
+	allPages, err := endpointgroups.ListForProject(client.ServiceClient(), "263fd9").AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := endpointgroups.ExtractEndpointGroups(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ExpectedEndpointGroupsSlice, actual)
+}
@@ -0,0 +1,44 @@
+package endpointgroups
+
+import "github.com/gophercloud/gophercloud/v2"
+
+const (
+	rootPath           = "OS-EP-FILTER"
+	endpointGroupsPath = "endpoint_groups"
+)
+
+func listURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath)
+}
+
+func getURL(client *gophercloud.ServiceClient, groupID string) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath, groupID)
+}
+
+func createURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath)
+}
+
+func updateURL(client *gophercloud.ServiceClient, groupID string) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath, groupID)
+}
+
+func deleteURL(client *gophercloud.ServiceClient, groupID string) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath, groupID)
+}
+
+func projectURL(client *gophercloud.ServiceClient, groupID, projectID string) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath, groupID, "projects", projectID)
+}
+
+func listProjectsURL(client *gophercloud.ServiceClient, groupID string) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath, groupID, "projects")
+}
+
+func listEndpointsURL(client *gophercloud.ServiceClient, groupID string) string {
+	return client.ServiceURL(rootPath, endpointGroupsPath, groupID, "endpoints")
+}
+
+func listForProjectURL(client *gophercloud.ServiceClient, projectID string) string {
+	return client.ServiceURL(rootPath, "projects", projectID, endpointGroupsPath)
+}
@@ -101,5 +101,71 @@ Example to Delete a Mapping
 	if err != nil {
 		panic(err)
 	}
+
+Example to List Identity Providers
+
+	listOpts := federation.ListIdentityProvidersOpts{
+		Enabled: &iTrue,
+	}
+
+	allPages, err := federation.ListIdentityProviders(identityClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allIdentityProviders, err := federation.ExtractIdentityProviders(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create an Identity Provider
+
+	createOpts := federation.CreateIdentityProviderOpts{
+		DomainID:    "1789d1",
+		Description: "Stores ACME identities",
+		Enabled:     &iTrue,
+		RemoteIDs:   []string{"https://idp.acme.example.com"},
+	}
+
+	idp, err := federation.CreateIdentityProvider(context.TODO(), identityClient, "ACME", createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Add a Protocol to an Identity Provider
+
+	createOpts := federation.CreateProtocolOpts{
+		MappingID: "ACME",
+	}
+
+	protocol, err := federation.CreateProtocol(context.TODO(), identityClient, "ACME", "openid", createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to Create a Service Provider
+
+	createOpts := federation.CreateServiceProviderOpts{
+		AuthURL: "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+		SPURL:   "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP",
+		Enabled: &iTrue,
+	}
+
+	sp, err := federation.CreateServiceProvider(context.TODO(), identityClient, "ACME-SP", createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example to List the Projects available to a Federated User
+
+	allPages, err := federation.ListProjects(identityClient).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		panic(err)
+	}
 */
 package federation
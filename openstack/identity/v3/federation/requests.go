@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/domains"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/projects"
 	"github.com/gophercloud/gophercloud/v2/pagination"
 )
 
@@ -89,3 +91,404 @@ func DeleteMapping(ctx context.Context, client *gophercloud.ServiceClient, mappi
 	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
 	return
 }
+
+// ListIdentityProvidersOptsBuilder allows extensions to add additional
+// parameters to the ListIdentityProviders request.
+type ListIdentityProvidersOptsBuilder interface {
+	ToIdentityProviderListQuery() (string, error)
+}
+
+// ListIdentityProvidersOpts provides options to filter the
+// ListIdentityProviders results.
+type ListIdentityProvidersOpts struct {
+	// ID filters the response by identity provider ID.
+	ID string `q:"id"`
+
+	// Enabled filters the response by enabled identity providers.
+	Enabled *bool `q:"enabled"`
+}
+
+// ToIdentityProviderListQuery formats a ListIdentityProvidersOpts into a
+// query string.
+func (opts ListIdentityProvidersOpts) ToIdentityProviderListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// ListIdentityProviders enumerates the identity providers.
+func ListIdentityProviders(client *gophercloud.ServiceClient, opts ListIdentityProvidersOptsBuilder) pagination.Pager {
+	url := identityProvidersRootURL(client)
+	if opts != nil {
+		query, err := opts.ToIdentityProviderListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return IdentityProvidersPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// CreateIdentityProviderOptsBuilder allows extensions to add additional
+// parameters to the CreateIdentityProvider request.
+type CreateIdentityProviderOptsBuilder interface {
+	ToIdentityProviderCreateMap() (map[string]any, error)
+}
+
+// CreateIdentityProviderOpts provides options for creating an identity
+// provider.
+type CreateIdentityProviderOpts struct {
+	// DomainID is the ID of the domain of the users of the identity provider.
+	// Keystone creates a domain when it is not set.
+	DomainID string `json:"domain_id,omitempty"`
+
+	// Description is the description of the identity provider.
+	Description string `json:"description,omitempty"`
+
+	// Enabled sets whether the identity provider is enabled. Keystone
+	// defaults to false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// AuthorizationTTL is the number of minutes the group memberships of
+	// the federated users remain valid.
+	AuthorizationTTL *int `json:"authorization_ttl,omitempty"`
+
+	// RemoteIDs are the IDs of the identity provider on the remote side,
+	// e.g. the entity ID of a SAML identity provider or the issuer of an
+	// OpenID Connect one.
+	RemoteIDs []string `json:"remote_ids,omitempty"`
+}
+
+// ToIdentityProviderCreateMap formats a CreateIdentityProviderOpts into a
+// create request.
+func (opts CreateIdentityProviderOpts) ToIdentityProviderCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "identity_provider")
+}
+
+// CreateIdentityProvider creates a new identity provider.
+func CreateIdentityProvider(ctx context.Context, client *gophercloud.ServiceClient, idpID string, opts CreateIdentityProviderOptsBuilder) (r CreateIdentityProviderResult) {
+	b, err := opts.ToIdentityProviderCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(ctx, identityProvidersResourceURL(client, idpID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetIdentityProvider retrieves details on a single identity provider, by ID.
+func GetIdentityProvider(ctx context.Context, client *gophercloud.ServiceClient, idpID string) (r GetIdentityProviderResult) {
+	resp, err := client.Get(ctx, identityProvidersResourceURL(client, idpID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateIdentityProviderOptsBuilder allows extensions to add additional
+// parameters to the UpdateIdentityProvider request.
+type UpdateIdentityProviderOptsBuilder interface {
+	ToIdentityProviderUpdateMap() (map[string]any, error)
+}
+
+// UpdateIdentityProviderOpts provides options for updating an identity
+// provider.
+type UpdateIdentityProviderOpts struct {
+	// Description is the description of the identity provider.
+	Description *string `json:"description,omitempty"`
+
+	// Enabled sets whether the identity provider is enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// AuthorizationTTL is the number of minutes the group memberships of
+	// the federated users remain valid.
+	AuthorizationTTL *int `json:"authorization_ttl,omitempty"`
+
+	// RemoteIDs are the IDs of the identity provider on the remote side.
+	RemoteIDs *[]string `json:"remote_ids,omitempty"`
+}
+
+// ToIdentityProviderUpdateMap formats a UpdateIdentityProviderOpts into an
+// update request.
+func (opts UpdateIdentityProviderOpts) ToIdentityProviderUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "identity_provider")
+}
+
+// UpdateIdentityProvider updates an existing identity provider.
+func UpdateIdentityProvider(ctx context.Context, client *gophercloud.ServiceClient, idpID string, opts UpdateIdentityProviderOptsBuilder) (r UpdateIdentityProviderResult) {
+	b, err := opts.ToIdentityProviderUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(ctx, identityProvidersResourceURL(client, idpID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteIdentityProvider deletes an identity provider, along with its
+// protocols.
+func DeleteIdentityProvider(ctx context.Context, client *gophercloud.ServiceClient, idpID string) (r DeleteIdentityProviderResult) {
+	resp, err := client.Delete(ctx, identityProvidersResourceURL(client, idpID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListProtocols enumerates the protocols of an identity provider.
+func ListProtocols(client *gophercloud.ServiceClient, idpID string) pagination.Pager {
+	return pagination.NewPager(client, protocolsRootURL(client, idpID), func(r pagination.PageResult) pagination.Page {
+		return ProtocolsPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// CreateProtocolOptsBuilder allows extensions to add additional parameters
+// to the CreateProtocol request.
+type CreateProtocolOptsBuilder interface {
+	ToProtocolCreateMap() (map[string]any, error)
+}
+
+// CreateProtocolOpts provides options for creating a protocol.
+type CreateProtocolOpts struct {
+	// MappingID is the ID of the mapping applied to the users
+	// authenticating with the protocol.
+	MappingID string `json:"mapping_id" required:"true"`
+
+	// RemoteIDAttribute is the attribute of the assertion holding the remote
+	// ID of the identity provider.
+	RemoteIDAttribute string `json:"remote_id_attribute,omitempty"`
+}
+
+// ToProtocolCreateMap formats a CreateProtocolOpts into a create request.
+func (opts CreateProtocolOpts) ToProtocolCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "protocol")
+}
+
+// CreateProtocol adds a protocol to an identity provider.
+func CreateProtocol(ctx context.Context, client *gophercloud.ServiceClient, idpID, protocolID string, opts CreateProtocolOptsBuilder) (r CreateProtocolResult) {
+	b, err := opts.ToProtocolCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(ctx, protocolsResourceURL(client, idpID, protocolID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetProtocol retrieves details on a single protocol of an identity provider.
+func GetProtocol(ctx context.Context, client *gophercloud.ServiceClient, idpID, protocolID string) (r GetProtocolResult) {
+	resp, err := client.Get(ctx, protocolsResourceURL(client, idpID, protocolID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateProtocolOptsBuilder allows extensions to add additional parameters
+// to the UpdateProtocol request.
+type UpdateProtocolOptsBuilder interface {
+	ToProtocolUpdateMap() (map[string]any, error)
+}
+
+// UpdateProtocolOpts provides options for updating a protocol.
+type UpdateProtocolOpts struct {
+	// MappingID is the ID of the mapping applied to the users
+	// authenticating with the protocol.
+	MappingID string `json:"mapping_id" required:"true"`
+
+	// RemoteIDAttribute is the attribute of the assertion holding the remote
+	// ID of the identity provider.
+	RemoteIDAttribute *string `json:"remote_id_attribute,omitempty"`
+}
+
+// ToProtocolUpdateMap formats a UpdateProtocolOpts into an update request.
+func (opts UpdateProtocolOpts) ToProtocolUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "protocol")
+}
+
+// UpdateProtocol updates a protocol of an identity provider.
+func UpdateProtocol(ctx context.Context, client *gophercloud.ServiceClient, idpID, protocolID string, opts UpdateProtocolOptsBuilder) (r UpdateProtocolResult) {
+	b, err := opts.ToProtocolUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(ctx, protocolsResourceURL(client, idpID, protocolID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteProtocol deletes a protocol of an identity provider.
+func DeleteProtocol(ctx context.Context, client *gophercloud.ServiceClient, idpID, protocolID string) (r DeleteProtocolResult) {
+	resp, err := client.Delete(ctx, protocolsResourceURL(client, idpID, protocolID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListServiceProvidersOptsBuilder allows extensions to add additional
+// parameters to the ListServiceProviders request.
+type ListServiceProvidersOptsBuilder interface {
+	ToServiceProviderListQuery() (string, error)
+}
+
+// ListServiceProvidersOpts provides options to filter the
+// ListServiceProviders results.
+type ListServiceProvidersOpts struct {
+	// ID filters the response by service provider ID.
+	ID string `q:"id"`
+
+	// Enabled filters the response by enabled service providers.
+	Enabled *bool `q:"enabled"`
+}
+
+// ToServiceProviderListQuery formats a ListServiceProvidersOpts into a query
+// string.
+func (opts ListServiceProvidersOpts) ToServiceProviderListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// ListServiceProviders enumerates the service providers, which are the
+// Keystone instances trusting this one in Keystone to Keystone federation.
+func ListServiceProviders(client *gophercloud.ServiceClient, opts ListServiceProvidersOptsBuilder) pagination.Pager {
+	url := serviceProvidersRootURL(client)
+	if opts != nil {
+		query, err := opts.ToServiceProviderListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return ServiceProvidersPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// CreateServiceProviderOptsBuilder allows extensions to add additional
+// parameters to the CreateServiceProvider request.
+type CreateServiceProviderOptsBuilder interface {
+	ToServiceProviderCreateMap() (map[string]any, error)
+}
+
+// CreateServiceProviderOpts provides options for creating a service
+// provider.
+type CreateServiceProviderOpts struct {
+	// AuthURL is the URL of the federated authentication endpoint of the
+	// service provider, to which SAML assertions are sent.
+	AuthURL string `json:"auth_url" required:"true"`
+
+	// SPURL is the URL of the SAML endpoint of the service provider.
+	SPURL string `json:"sp_url" required:"true"`
+
+	// Description is the description of the service provider.
+	Description string `json:"description,omitempty"`
+
+	// Enabled sets whether the service provider is enabled. Keystone
+	// defaults to false.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RelayStatePrefix is the prefix of the RelayState SAML attribute.
+	RelayStatePrefix string `json:"relay_state_prefix,omitempty"`
+}
+
+// ToServiceProviderCreateMap formats a CreateServiceProviderOpts into a
+// create request.
+func (opts CreateServiceProviderOpts) ToServiceProviderCreateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "service_provider")
+}
+
+// CreateServiceProvider creates a new service provider.
+func CreateServiceProvider(ctx context.Context, client *gophercloud.ServiceClient, spID string, opts CreateServiceProviderOptsBuilder) (r CreateServiceProviderResult) {
+	b, err := opts.ToServiceProviderCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Put(ctx, serviceProvidersResourceURL(client, spID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// GetServiceProvider retrieves details on a single service provider, by ID.
+func GetServiceProvider(ctx context.Context, client *gophercloud.ServiceClient, spID string) (r GetServiceProviderResult) {
+	resp, err := client.Get(ctx, serviceProvidersResourceURL(client, spID), &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// UpdateServiceProviderOptsBuilder allows extensions to add additional
+// parameters to the UpdateServiceProvider request.
+type UpdateServiceProviderOptsBuilder interface {
+	ToServiceProviderUpdateMap() (map[string]any, error)
+}
+
+// UpdateServiceProviderOpts provides options for updating a service
+// provider.
+type UpdateServiceProviderOpts struct {
+	// AuthURL is the URL of the federated authentication endpoint of the
+	// service provider.
+	AuthURL string `json:"auth_url,omitempty"`
+
+	// SPURL is the URL of the SAML endpoint of the service provider.
+	SPURL string `json:"sp_url,omitempty"`
+
+	// Description is the description of the service provider.
+	Description *string `json:"description,omitempty"`
+
+	// Enabled sets whether the service provider is enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RelayStatePrefix is the prefix of the RelayState SAML attribute.
+	RelayStatePrefix *string `json:"relay_state_prefix,omitempty"`
+}
+
+// ToServiceProviderUpdateMap formats a UpdateServiceProviderOpts into an
+// update request.
+func (opts UpdateServiceProviderOpts) ToServiceProviderUpdateMap() (map[string]any, error) {
+	return gophercloud.BuildRequestBody(opts, "service_provider")
+}
+
+// UpdateServiceProvider updates an existing service provider.
+func UpdateServiceProvider(ctx context.Context, client *gophercloud.ServiceClient, spID string, opts UpdateServiceProviderOptsBuilder) (r UpdateServiceProviderResult) {
+	b, err := opts.ToServiceProviderUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	resp, err := client.Patch(ctx, serviceProvidersResourceURL(client, spID), &b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// DeleteServiceProvider deletes a service provider.
+func DeleteServiceProvider(ctx context.Context, client *gophercloud.ServiceClient, spID string) (r DeleteServiceProviderResult) {
+	resp, err := client.Delete(ctx, serviceProvidersResourceURL(client, spID), nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// ListProjects enumerates the projects available to the federated user of
+// the token. Use projects.ExtractProjects to interpret the pages.
+func ListProjects(client *gophercloud.ServiceClient) pagination.Pager {
+	return pagination.NewPager(client, projectsURL(client), func(r pagination.PageResult) pagination.Page {
+		return projects.ProjectPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
+
+// ListDomains enumerates the domains available to the federated user of the
+// token. Use domains.ExtractDomains to interpret the pages.
+func ListDomains(client *gophercloud.ServiceClient) pagination.Pager {
+	return pagination.NewPager(client, domainsURL(client), func(r pagination.PageResult) pagination.Page {
+		return domains.DomainPage{LinkedPageBase: pagination.LinkedPageBase{PageResult: r}}
+	})
+}
@@ -343,3 +343,482 @@ func HandleDeleteMappingSuccessfully(t *testing.T) {
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
+
+const ListIdentityProvidersOutput = `
+{
+    "identity_providers": [
+        {
+            "domain_id": "1789d1",
+            "description": "Stores ACME identities",
+            "enabled": true,
+            "authorization_ttl": null,
+            "id": "ACME",
+            "remote_ids": [
+                "https://idp.acme.example.com"
+            ],
+            "links": {
+                "protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+                "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME"
+            }
+        }
+    ],
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers"
+    }
+}
+`
+
+const CreateIdentityProviderRequest = `
+{
+    "identity_provider": {
+        "domain_id": "1789d1",
+        "description": "Stores ACME identities",
+        "enabled": true,
+        "remote_ids": [
+            "https://idp.acme.example.com"
+        ]
+    }
+}
+`
+
+const GetIdentityProviderOutput = `
+{
+    "identity_provider": {
+        "domain_id": "1789d1",
+        "description": "Stores ACME identities",
+        "enabled": true,
+        "authorization_ttl": null,
+        "id": "ACME",
+        "remote_ids": [
+            "https://idp.acme.example.com"
+        ],
+        "links": {
+            "protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+            "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME"
+        }
+    }
+}
+`
+
+const UpdateIdentityProviderRequest = `
+{
+    "identity_provider": {
+        "enabled": false,
+        "authorization_ttl": 60
+    }
+}
+`
+
+const UpdateIdentityProviderOutput = `
+{
+    "identity_provider": {
+        "domain_id": "1789d1",
+        "description": "Stores ACME identities",
+        "enabled": false,
+        "authorization_ttl": 60,
+        "id": "ACME",
+        "remote_ids": [
+            "https://idp.acme.example.com"
+        ],
+        "links": {
+            "protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+            "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME"
+        }
+    }
+}
+`
+
+const ListProtocolsOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols"
+    },
+    "protocols": [
+        {
+            "id": "saml2",
+            "mapping_id": "ACME",
+            "remote_id_attribute": "",
+            "links": {
+                "identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+                "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols/saml2"
+            }
+        }
+    ]
+}
+`
+
+const CreateProtocolRequest = `
+{
+    "protocol": {
+        "mapping_id": "ACME"
+    }
+}
+`
+
+const GetProtocolOutput = `
+{
+    "protocol": {
+        "id": "saml2",
+        "mapping_id": "ACME",
+        "remote_id_attribute": "",
+        "links": {
+            "identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+            "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols/saml2"
+        }
+    }
+}
+`
+
+const UpdateProtocolRequest = `
+{
+    "protocol": {
+        "mapping_id": "ACME",
+        "remote_id_attribute": "Shib-Identity-Provider"
+    }
+}
+`
+
+const UpdateProtocolOutput = `
+{
+    "protocol": {
+        "id": "saml2",
+        "mapping_id": "ACME",
+        "remote_id_attribute": "Shib-Identity-Provider",
+        "links": {
+            "identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+            "self": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols/saml2"
+        }
+    }
+}
+`
+
+const ListServiceProvidersOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/service_providers"
+    },
+    "service_providers": [
+        {
+            "auth_url": "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+            "description": "Remote region",
+            "enabled": true,
+            "id": "ACME-SP",
+            "relay_state_prefix": "ss:mem:",
+            "sp_url": "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP",
+            "links": {
+                "self": "http://example.com/identity/v3/OS-FEDERATION/service_providers/ACME-SP"
+            }
+        }
+    ]
+}
+`
+
+const CreateServiceProviderRequest = `
+{
+    "service_provider": {
+        "auth_url": "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+        "description": "Remote region",
+        "enabled": true,
+        "sp_url": "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP"
+    }
+}
+`
+
+const GetServiceProviderOutput = `
+{
+    "service_provider": {
+        "auth_url": "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+        "description": "Remote region",
+        "enabled": true,
+        "id": "ACME-SP",
+        "relay_state_prefix": "ss:mem:",
+        "sp_url": "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP",
+        "links": {
+            "self": "http://example.com/identity/v3/OS-FEDERATION/service_providers/ACME-SP"
+        }
+    }
+}
+`
+
+const UpdateServiceProviderRequest = `
+{
+    "service_provider": {
+        "enabled": false
+    }
+}
+`
+
+const UpdateServiceProviderOutput = `
+{
+    "service_provider": {
+        "auth_url": "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+        "description": "Remote region",
+        "enabled": false,
+        "id": "ACME-SP",
+        "relay_state_prefix": "ss:mem:",
+        "sp_url": "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP",
+        "links": {
+            "self": "http://example.com/identity/v3/OS-FEDERATION/service_providers/ACME-SP"
+        }
+    }
+}
+`
+
+const ListProjectsOutput = `
+{
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/projects"
+    },
+    "projects": [
+        {
+            "domain_id": "1789d1",
+            "enabled": true,
+            "id": "263fd9",
+            "links": {
+                "self": "http://example.com/identity/v3/projects/263fd9"
+            },
+            "name": "Test Group"
+        }
+    ]
+}
+`
+
+const ListDomainsOutput = `
+{
+    "domains": [
+        {
+            "description": "desc of domain",
+            "enabled": true,
+            "id": "37ef61",
+            "links": {
+                "self": "http://example.com/identity/v3/domains/37ef61"
+            },
+            "name": "my domain"
+        }
+    ],
+    "links": {
+        "next": null,
+        "previous": null,
+        "self": "http://example.com/identity/v3/OS-FEDERATION/domains"
+    }
+}
+`
+
+var IdentityProviderACME = federation.IdentityProvider{
+	ID:          "ACME",
+	DomainID:    "1789d1",
+	Description: "Stores ACME identities",
+	Enabled:     true,
+	RemoteIDs:   []string{"https://idp.acme.example.com"},
+	Links: map[string]any{
+		"protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+		"self":      "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+	},
+}
+
+var authorizationTTL = 60
+
+var IdentityProviderUpdated = federation.IdentityProvider{
+	ID:               "ACME",
+	DomainID:         "1789d1",
+	Description:      "Stores ACME identities",
+	Enabled:          false,
+	AuthorizationTTL: &authorizationTTL,
+	RemoteIDs:        []string{"https://idp.acme.example.com"},
+	Links: map[string]any{
+		"protocols": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols",
+		"self":      "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+	},
+}
+
+var ProtocolSAML2 = federation.Protocol{
+	ID:        "saml2",
+	MappingID: "ACME",
+	Links: map[string]any{
+		"identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+		"self":              "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols/saml2",
+	},
+}
+
+var ProtocolUpdated = federation.Protocol{
+	ID:                "saml2",
+	MappingID:         "ACME",
+	RemoteIDAttribute: "Shib-Identity-Provider",
+	Links: map[string]any{
+		"identity_provider": "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME",
+		"self":              "http://example.com/identity/v3/OS-FEDERATION/identity_providers/ACME/protocols/saml2",
+	},
+}
+
+var ServiceProviderACME = federation.ServiceProvider{
+	ID:               "ACME-SP",
+	AuthURL:          "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+	SPURL:            "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP",
+	Description:      "Remote region",
+	Enabled:          true,
+	RelayStatePrefix: "ss:mem:",
+	Links: map[string]any{
+		"self": "http://example.com/identity/v3/OS-FEDERATION/service_providers/ACME-SP",
+	},
+}
+
+var ServiceProviderUpdated = federation.ServiceProvider{
+	ID:               "ACME-SP",
+	AuthURL:          "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+	SPURL:            "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP",
+	Description:      "Remote region",
+	Enabled:          false,
+	RelayStatePrefix: "ss:mem:",
+	Links: map[string]any{
+		"self": "http://example.com/identity/v3/OS-FEDERATION/service_providers/ACME-SP",
+	},
+}
+
+// handleSuccessfully creates an HTTP handler at path on the test handler mux
+// that checks the request method and body, and responds with output.
+func handleSuccessfully(t *testing.T, path, method, request string, status int, output string) {
+	th.Mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, method)
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		if request != "" {
+			th.TestJSONRequest(t, r, request)
+		}
+
+		if output == "" {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, output)
+	})
+}
+
+// HandleListIdentityProvidersSuccessfully creates an HTTP handler at
+// `/identity_providers` on the test handler mux that responds with a list of
+// identity providers.
+func HandleListIdentityProvidersSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{"enabled": "true"})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListIdentityProvidersOutput)
+	})
+}
+
+// HandleCreateIdentityProviderSuccessfully creates an HTTP handler at
+// `/identity_providers` on the test handler mux that tests identity provider
+// creation.
+func HandleCreateIdentityProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME", "PUT", CreateIdentityProviderRequest, http.StatusCreated, GetIdentityProviderOutput)
+}
+
+// HandleGetIdentityProviderSuccessfully creates an HTTP handler at
+// `/identity_providers` on the test handler mux that responds with a single
+// identity provider.
+func HandleGetIdentityProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME", "GET", "", http.StatusOK, GetIdentityProviderOutput)
+}
+
+// HandleUpdateIdentityProviderSuccessfully creates an HTTP handler at
+// `/identity_providers` on the test handler mux that tests identity provider
+// update.
+func HandleUpdateIdentityProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME", "PATCH", UpdateIdentityProviderRequest, http.StatusOK, UpdateIdentityProviderOutput)
+}
+
+// HandleDeleteIdentityProviderSuccessfully creates an HTTP handler at
+// `/identity_providers` on the test handler mux that tests identity provider
+// deletion.
+func HandleDeleteIdentityProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME", "DELETE", "", http.StatusNoContent, "")
+}
+
+// HandleListProtocolsSuccessfully creates an HTTP handler at `/protocols` on
+// the test handler mux that responds with a list of protocols.
+func HandleListProtocolsSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME/protocols", "GET", "", http.StatusOK, ListProtocolsOutput)
+}
+
+// HandleCreateProtocolSuccessfully creates an HTTP handler at `/protocols` on
+// the test handler mux that tests protocol creation.
+func HandleCreateProtocolSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME/protocols/saml2", "PUT", CreateProtocolRequest, http.StatusCreated, GetProtocolOutput)
+}
+
+// HandleGetProtocolSuccessfully creates an HTTP handler at `/protocols` on the
+// test handler mux that responds with a single protocol.
+func HandleGetProtocolSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME/protocols/saml2", "GET", "", http.StatusOK, GetProtocolOutput)
+}
+
+// HandleUpdateProtocolSuccessfully creates an HTTP handler at `/protocols` on
+// the test handler mux that tests protocol update.
+func HandleUpdateProtocolSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME/protocols/saml2", "PATCH", UpdateProtocolRequest, http.StatusOK, UpdateProtocolOutput)
+}
+
+// HandleDeleteProtocolSuccessfully creates an HTTP handler at `/protocols` on
+// the test handler mux that tests protocol deletion.
+func HandleDeleteProtocolSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/identity_providers/ACME/protocols/saml2", "DELETE", "", http.StatusNoContent, "")
+}
+
+// HandleListServiceProvidersSuccessfully creates an HTTP handler at
+// `/service_providers` on the test handler mux that responds with a list of
+// service providers.
+func HandleListServiceProvidersSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/service_providers", "GET", "", http.StatusOK, ListServiceProvidersOutput)
+}
+
+// HandleCreateServiceProviderSuccessfully creates an HTTP handler at
+// `/service_providers` on the test handler mux that tests service provider
+// creation.
+func HandleCreateServiceProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/service_providers/ACME-SP", "PUT", CreateServiceProviderRequest, http.StatusCreated, GetServiceProviderOutput)
+}
+
+// HandleGetServiceProviderSuccessfully creates an HTTP handler at
+// `/service_providers` on the test handler mux that responds with a single
+// service provider.
+func HandleGetServiceProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/service_providers/ACME-SP", "GET", "", http.StatusOK, GetServiceProviderOutput)
+}
+
+// HandleUpdateServiceProviderSuccessfully creates an HTTP handler at
+// `/service_providers` on the test handler mux that tests service provider
+// update.
+func HandleUpdateServiceProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/service_providers/ACME-SP", "PATCH", UpdateServiceProviderRequest, http.StatusOK, UpdateServiceProviderOutput)
+}
+
+// HandleDeleteServiceProviderSuccessfully creates an HTTP handler at
+// `/service_providers` on the test handler mux that tests service provider
+// deletion.
+func HandleDeleteServiceProviderSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/service_providers/ACME-SP", "DELETE", "", http.StatusNoContent, "")
+}
+
+// HandleListProjectsSuccessfully creates an HTTP handler at `/projects` on the
+// test handler mux that responds with the projects of the federated user.
+func HandleListProjectsSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/projects", "GET", "", http.StatusOK, ListProjectsOutput)
+}
+
+// HandleListDomainsSuccessfully creates an HTTP handler at `/domains` on the
+// test handler mux that responds with the domains of the federated user.
+func HandleListDomainsSuccessfully(t *testing.T) {
+	handleSuccessfully(t, "/OS-FEDERATION/domains", "GET", "", http.StatusOK, ListDomainsOutput)
+}
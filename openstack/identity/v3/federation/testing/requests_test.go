@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/domains"
 	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/federation"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/projects"
 	"github.com/gophercloud/gophercloud/v2/pagination"
 	th "github.com/gophercloud/gophercloud/v2/testhelper"
 	"github.com/gophercloud/gophercloud/v2/testhelper/client"
@@ -142,3 +144,228 @@ func TestDeleteMapping(t *testing.T) {
 	res := federation.DeleteMapping(context.TODO(), client.ServiceClient(), "ACME")
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestListIdentityProviders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListIdentityProvidersSuccessfully(t)
+
+	iTrue := true
+	allPages, err := federation.ListIdentityProviders(client.ServiceClient(), federation.ListIdentityProvidersOpts{Enabled: &iTrue}).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractIdentityProviders(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.IdentityProvider{IdentityProviderACME}, actual)
+}
+
+func TestCreateIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateIdentityProviderSuccessfully(t)
+
+	iTrue := true
+	createOpts := federation.CreateIdentityProviderOpts{
+		DomainID:    "1789d1",
+		Description: "Stores ACME identities",
+		Enabled:     &iTrue,
+		RemoteIDs:   []string{"https://idp.acme.example.com"},
+	}
+
+	actual, err := federation.CreateIdentityProvider(context.TODO(), client.ServiceClient(), "ACME", createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, IdentityProviderACME, *actual)
+}
+
+func TestGetIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetIdentityProviderSuccessfully(t)
+
+	actual, err := federation.GetIdentityProvider(context.TODO(), client.ServiceClient(), "ACME").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, IdentityProviderACME, *actual)
+}
+
+func TestUpdateIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateIdentityProviderSuccessfully(t)
+
+	iFalse := false
+	ttl := 60
+	updateOpts := federation.UpdateIdentityProviderOpts{
+		Enabled:          &iFalse,
+		AuthorizationTTL: &ttl,
+	}
+
+	actual, err := federation.UpdateIdentityProvider(context.TODO(), client.ServiceClient(), "ACME", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, IdentityProviderUpdated, *actual)
+}
+
+func TestDeleteIdentityProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteIdentityProviderSuccessfully(t)
+
+	res := federation.DeleteIdentityProvider(context.TODO(), client.ServiceClient(), "ACME")
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestListProtocols(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListProtocolsSuccessfully(t)
+
+	allPages, err := federation.ListProtocols(client.ServiceClient(), "ACME").AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractProtocols(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.Protocol{ProtocolSAML2}, actual)
+}
+
+func TestCreateProtocol(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateProtocolSuccessfully(t)
+
+	createOpts := federation.CreateProtocolOpts{
+		MappingID: "ACME",
+	}
+
+	actual, err := federation.CreateProtocol(context.TODO(), client.ServiceClient(), "ACME", "saml2", createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ProtocolSAML2, *actual)
+}
+
+func TestCreateProtocolMissingMapping(t *testing.T) {
+	res := federation.CreateProtocol(context.TODO(), client.ServiceClient(), "ACME", "saml2", federation.CreateProtocolOpts{})
+	th.AssertErr(t, res.Err)
+}
+
+func TestGetProtocol(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetProtocolSuccessfully(t)
+
+	actual, err := federation.GetProtocol(context.TODO(), client.ServiceClient(), "ACME", "saml2").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ProtocolSAML2, *actual)
+}
+
+func TestUpdateProtocol(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateProtocolSuccessfully(t)
+
+	remoteIDAttribute := "Shib-Identity-Provider"
+	updateOpts := federation.UpdateProtocolOpts{
+		MappingID:         "ACME",
+		RemoteIDAttribute: &remoteIDAttribute,
+	}
+
+	actual, err := federation.UpdateProtocol(context.TODO(), client.ServiceClient(), "ACME", "saml2", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ProtocolUpdated, *actual)
+}
+
+func TestDeleteProtocol(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteProtocolSuccessfully(t)
+
+	res := federation.DeleteProtocol(context.TODO(), client.ServiceClient(), "ACME", "saml2")
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestListServiceProviders(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListServiceProvidersSuccessfully(t)
+
+	allPages, err := federation.ListServiceProviders(client.ServiceClient(), nil).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := federation.ExtractServiceProviders(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []federation.ServiceProvider{ServiceProviderACME}, actual)
+}
+
+func TestCreateServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleCreateServiceProviderSuccessfully(t)
+
+	iTrue := true
+	createOpts := federation.CreateServiceProviderOpts{
+		AuthURL:     "https://sp.example.com:5000/v3/OS-FEDERATION/identity_providers/acme/protocols/saml2/auth",
+		SPURL:       "https://sp.example.com:5000/Shibboleth.sso/SAML2/ECP",
+		Description: "Remote region",
+		Enabled:     &iTrue,
+	}
+
+	actual, err := federation.CreateServiceProvider(context.TODO(), client.ServiceClient(), "ACME-SP", createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ServiceProviderACME, *actual)
+}
+
+func TestGetServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetServiceProviderSuccessfully(t)
+
+	actual, err := federation.GetServiceProvider(context.TODO(), client.ServiceClient(), "ACME-SP").Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ServiceProviderACME, *actual)
+}
+
+func TestUpdateServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleUpdateServiceProviderSuccessfully(t)
+
+	iFalse := false
+	updateOpts := federation.UpdateServiceProviderOpts{
+		Enabled: &iFalse,
+	}
+
+	actual, err := federation.UpdateServiceProvider(context.TODO(), client.ServiceClient(), "ACME-SP", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ServiceProviderUpdated, *actual)
+}
+
+func TestDeleteServiceProvider(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDeleteServiceProviderSuccessfully(t)
+
+	res := federation.DeleteServiceProvider(context.TODO(), client.ServiceClient(), "ACME-SP")
+	th.AssertNoErr(t, res.Err)
+}
+
+func TestListProjects(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListProjectsSuccessfully(t)
+
+	allPages, err := federation.ListProjects(client.ServiceClient()).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := projects.ExtractProjects(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual))
+	th.AssertEquals(t, "263fd9", actual[0].ID)
+	th.AssertEquals(t, "Test Group", actual[0].Name)
+}
+
+func TestListDomains(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListDomainsSuccessfully(t)
+
+	allPages, err := federation.ListDomains(client.ServiceClient()).AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+	actual, err := domains.ExtractDomains(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual))
+	th.AssertEquals(t, "37ef61", actual[0].ID)
+	th.AssertEquals(t, "my domain", actual[0].Name)
+}
@@ -14,3 +14,43 @@ func mappingsRootURL(c *gophercloud.ServiceClient) string {
 func mappingsResourceURL(c *gophercloud.ServiceClient, mappingID string) string {
 	return c.ServiceURL(rootPath, mappingsPath, mappingID)
 }
+
+const (
+	identityProvidersPath = "identity_providers"
+	protocolsPath         = "protocols"
+	serviceProvidersPath  = "service_providers"
+	projectsPath          = "projects"
+	domainsPath           = "domains"
+)
+
+func identityProvidersRootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, identityProvidersPath)
+}
+
+func identityProvidersResourceURL(c *gophercloud.ServiceClient, idpID string) string {
+	return c.ServiceURL(rootPath, identityProvidersPath, idpID)
+}
+
+func protocolsRootURL(c *gophercloud.ServiceClient, idpID string) string {
+	return c.ServiceURL(rootPath, identityProvidersPath, idpID, protocolsPath)
+}
+
+func protocolsResourceURL(c *gophercloud.ServiceClient, idpID, protocolID string) string {
+	return c.ServiceURL(rootPath, identityProvidersPath, idpID, protocolsPath, protocolID)
+}
+
+func serviceProvidersRootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, serviceProvidersPath)
+}
+
+func serviceProvidersResourceURL(c *gophercloud.ServiceClient, spID string) string {
+	return c.ServiceURL(rootPath, serviceProvidersPath, spID)
+}
+
+func projectsURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, projectsPath)
+}
+
+func domainsURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(rootPath, domainsPath)
+}
@@ -0,0 +1,54 @@
+/*
+Package oidc enables authentication through an OpenID Connect identity
+provider federated with Keystone.
+
+An access token is obtained from the identity provider, and exchanged for an
+unscoped Keystone token at the
+/OS-FEDERATION/identity_providers/{idp}/protocols/{protocol}/auth endpoint.
+When a scope is requested, the unscoped token is then rescoped.
+
+Example to Authenticate with the Password Grant
+
+	authOptions := oidc.AuthOptions{
+		OIDCAuthOptions: gophercloud.OIDCAuthOptions{
+			IdentityProvider:  "myidp",
+			Protocol:          "openid",
+			GrantType:         gophercloud.OIDCGrantPassword,
+			ClientID:          "openstack",
+			ClientSecret:      "secret",
+			DiscoveryEndpoint: "https://idp.example.com/.well-known/openid-configuration",
+		},
+		Username: "demo",
+		Password: "secret",
+		Scope: tokens.Scope{
+			ProjectName: "demo",
+			DomainName:  "Default",
+		},
+		AllowReauth: true,
+	}
+
+	err := openstack.AuthenticateV3(context.TODO(), providerClient, &authOptions, gophercloud.EndpointOpts{})
+	if err != nil {
+		panic(err)
+	}
+
+Example to Authenticate with an Access Token
+
+	authOptions := oidc.AuthOptions{
+		OIDCAuthOptions: gophercloud.OIDCAuthOptions{
+			IdentityProvider: "myidp",
+			Protocol:         "openid",
+			AccessToken:      accessToken,
+		},
+	}
+
+	token, err := oidc.Create(context.TODO(), identityClient, authOptions).ExtractToken()
+	if err != nil {
+		panic(err)
+	}
+
+The OIDC field of gophercloud.AuthOptions can be used instead, to authenticate
+with openstack.AuthenticatedClient or with a clouds.yaml auth_type of
+v3oidcpassword, v3oidcclientcredentials, v3oidcaccesstoken or v3oidcauthcode.
+*/
+package oidc
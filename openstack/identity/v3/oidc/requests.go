@@ -0,0 +1,240 @@
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+)
+
+// DefaultScopes are the OpenID Connect scopes requested when none are set.
+var DefaultScopes = []string{"openid", "profile"}
+
+// AuthOptions represents options for authenticating a user through an OpenID
+// Connect identity provider.
+type AuthOptions struct {
+	gophercloud.OIDCAuthOptions
+
+	// Username and Password are the credentials of the user at the identity
+	// provider, used with the OIDCGrantPassword grant type.
+	Username string
+	Password string
+
+	// Scope is the scope of the token. The unscoped token obtained from the
+	// identity provider is returned when it is empty.
+	Scope tokens.Scope
+
+	// AllowReauth allows Gophercloud to re-authenticate automatically
+	// if/when your token expires.
+	AllowReauth bool
+}
+
+// ToTokenV3ScopeMap builds a scope request body from AuthOptions.
+func (opts AuthOptions) ToTokenV3ScopeMap() (map[string]any, error) {
+	scope := gophercloud.AuthScope(opts.Scope)
+
+	gophercloudAuthOpts := gophercloud.AuthOptions{
+		Scope: &scope,
+	}
+
+	return gophercloudAuthOpts.ToTokenV3ScopeMap()
+}
+
+// CanReauth allows AuthOptions to satisfy the tokens.AuthOptionsBuilder
+// interface. Re-authentication is not possible with an authorization code,
+// which can only be used once.
+func (opts AuthOptions) CanReauth() bool {
+	if opts.AccessToken == "" && opts.GrantType == gophercloud.OIDCGrantAuthorizationCode {
+		return false
+	}
+	return opts.AllowReauth
+}
+
+// ToTokenV3HeadersMap allows AuthOptions to satisfy the
+// tokens.AuthOptionsBuilder interface.
+func (opts AuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
+	return nil, nil
+}
+
+// ToTokenV3CreateMap allows AuthOptions to satisfy the
+// tokens.AuthOptionsBuilder interface. Tokens cannot be created from
+// AuthOptions with tokens.Create: use Create instead.
+func (opts AuthOptions) ToTokenV3CreateMap(map[string]any) (map[string]any, error) {
+	return nil, fmt.Errorf("OpenID Connect tokens must be created with oidc.Create")
+}
+
+// Create authenticates with the identity provider, exchanges the access token
+// for an unscoped token, and rescopes it if AuthOptions has a scope.
+func Create(ctx context.Context, client *gophercloud.ServiceClient, opts AuthOptions) (r tokens.CreateResult) {
+	if opts.IdentityProvider == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "IdentityProvider"}
+		return
+	}
+	if opts.Protocol == "" {
+		r.Err = gophercloud.ErrMissingInput{Argument: "Protocol"}
+		return
+	}
+
+	scope, err := opts.ToTokenV3ScopeMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	accessToken := opts.AccessToken
+	if accessToken == "" {
+		accessToken, err = requestAccessToken(ctx, client.ProviderClient, opts)
+		if err != nil {
+			r.Err = err
+			return
+		}
+	}
+
+	resp, err := client.Post(ctx, federatedAuthURL(client, opts.IdentityProvider, opts.Protocol), nil, &r.Body, &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{"Authorization": "Bearer " + accessToken},
+		OmitHeaders: []string{"X-Auth-Token"},
+		OkCodes:     []int{201},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	if r.Err != nil || scope == nil {
+		return
+	}
+
+	unscopedTokenID, err := r.ExtractTokenID()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	b := map[string]any{
+		"auth": map[string]any{
+			"identity": map[string]any{
+				"methods": []string{"token"},
+				"token":   map[string]any{"id": unscopedTokenID},
+			},
+			"scope": scope,
+		},
+	}
+
+	r = tokens.CreateResult{}
+	resp, err = client.Post(ctx, tokenURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OmitHeaders: []string{"X-Auth-Token"},
+	})
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
+// requestAccessToken obtains an access token from the token endpoint of the
+// identity provider.
+func requestAccessToken(ctx context.Context, client *gophercloud.ProviderClient, opts AuthOptions) (string, error) {
+	scopes := opts.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+
+	form := url.Values{
+		"grant_type": {string(opts.GrantType)},
+		"scope":      {strings.Join(scopes, " ")},
+	}
+	switch opts.GrantType {
+	case gophercloud.OIDCGrantPassword:
+		if opts.Username == "" {
+			return "", gophercloud.ErrMissingInput{Argument: "Username"}
+		}
+		if opts.Password == "" {
+			return "", gophercloud.ErrMissingInput{Argument: "Password"}
+		}
+		form.Set("username", opts.Username)
+		form.Set("password", opts.Password)
+	case gophercloud.OIDCGrantClientCredentials:
+		if opts.ClientSecret == "" {
+			return "", gophercloud.ErrMissingInput{Argument: "ClientSecret"}
+		}
+	case gophercloud.OIDCGrantAuthorizationCode:
+		if opts.AuthorizationCode == "" {
+			return "", gophercloud.ErrMissingInput{Argument: "AuthorizationCode"}
+		}
+		form.Set("code", opts.AuthorizationCode)
+		form.Set("redirect_uri", opts.RedirectURI)
+	case "":
+		return "", gophercloud.ErrMissingInput{Argument: "GrantType"}
+	default:
+		return "", gophercloud.ErrInvalidInput{
+			ErrMissingInput: gophercloud.ErrMissingInput{Argument: "GrantType"},
+			Value:           opts.GrantType,
+		}
+	}
+
+	if opts.ClientID == "" {
+		return "", gophercloud.ErrMissingInput{Argument: "ClientID"}
+	}
+	headers := map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+		"Accept":       "application/json",
+	}
+	if opts.ClientSecret != "" {
+		credentials := url.QueryEscape(opts.ClientID) + ":" + url.QueryEscape(opts.ClientSecret)
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	} else {
+		form.Set("client_id", opts.ClientID)
+	}
+
+	tokenEndpoint, err := accessTokenEndpoint(ctx, client, opts)
+	if err != nil {
+		return "", err
+	}
+
+	var body map[string]any
+	_, err = client.Request(ctx, http.MethodPost, tokenEndpoint, &gophercloud.RequestOpts{
+		RawBody:      strings.NewReader(form.Encode()),
+		JSONResponse: &body,
+		MoreHeaders:  headers,
+		OmitHeaders:  []string{"X-Auth-Token"},
+		OkCodes:      []int{200},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	tokenType := opts.AccessTokenType
+	if tokenType == "" {
+		tokenType = "access_token"
+	}
+	token, ok := body[tokenType].(string)
+	if !ok || token == "" {
+		return "", fmt.Errorf("the token endpoint response has no %q", tokenType)
+	}
+	return token, nil
+}
+
+// accessTokenEndpoint returns the token endpoint of the identity provider,
+// reading it from the discovery document if needed.
+func accessTokenEndpoint(ctx context.Context, client *gophercloud.ProviderClient, opts AuthOptions) (string, error) {
+	if opts.AccessTokenEndpoint != "" {
+		return opts.AccessTokenEndpoint, nil
+	}
+	if opts.DiscoveryEndpoint == "" {
+		return "", gophercloud.ErrMissingInput{Argument: "AccessTokenEndpoint"}
+	}
+
+	var discovery struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	_, err := client.Request(ctx, http.MethodGet, opts.DiscoveryEndpoint, &gophercloud.RequestOpts{
+		JSONResponse: &discovery,
+		OmitHeaders:  []string{"X-Auth-Token"},
+		OkCodes:      []int{200},
+	})
+	if err != nil {
+		return "", err
+	}
+	if discovery.TokenEndpoint == "" {
+		return "", fmt.Errorf("the discovery document of %s has no token_endpoint", opts.DiscoveryEndpoint)
+	}
+	return discovery.TokenEndpoint, nil
+}
@@ -0,0 +1,123 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	tokens "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens/testing"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+// IdPAccessToken is the access token issued by the fake identity provider.
+const IdPAccessToken = "eyJhbGciOiJSUzI1NiJ9.fake"
+
+// UnscopedTokenID is the ID of the unscoped token issued by Keystone in
+// exchange for IdPAccessToken.
+const UnscopedTokenID = "6e7b3e5e3bd443f2a8d5b7d4a1c0e2f3"
+
+// ScopedTokenID is the ID of the token rescoped from UnscopedTokenID.
+const ScopedTokenID = "9f1c2a3b4c5d6e7f8091a2b3c4d5e6f7"
+
+// UnscopedTokenOutput is the response of the federated authentication.
+const UnscopedTokenOutput = `
+{
+	"token": {
+		"methods": ["openid"],
+		"expires_at": "2017-06-03T02:19:49.000000Z",
+		"user": {
+			"domain": {"id": "Federated", "name": "Federated"},
+			"id": "4d2d3ca8b2fe4a0d8ab8b5c2f0d8e1a9",
+			"name": "demo",
+			"OS-FEDERATION": {
+				"identity_provider": {"id": "myidp"},
+				"protocol": {"id": "openid"},
+				"groups": [{"id": "8f3c6e2b1a0d4c5e9b7a6f5e4d3c2b1a"}]
+			}
+		}
+	}
+}
+`
+
+// RescopeRequest is the request to rescope UnscopedTokenID to a project.
+const RescopeRequest = `
+{
+	"auth": {
+		"identity": {
+			"methods": ["token"],
+			"token": {"id": "6e7b3e5e3bd443f2a8d5b7d4a1c0e2f3"}
+		},
+		"scope": {
+			"project": {"name": "demo", "domain": {"name": "Default"}}
+		}
+	}
+}
+`
+
+// HandleDiscovery creates an HTTP handler serving the OpenID Connect
+// discovery document of the fake identity provider.
+func HandleDiscovery(t *testing.T) {
+	th.Mux.HandleFunc("/idp/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeaderUnset(t, r, "X-Auth-Token")
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"issuer": "%[1]sidp", "token_endpoint": "%[1]sidp/token"}`, th.Endpoint())
+	})
+}
+
+// HandleIdPToken creates an HTTP handler at the token endpoint of the fake
+// identity provider, which expects the given form values and client
+// credentials. It returns a counter of the access tokens issued.
+func HandleIdPToken(t *testing.T, form map[string]string, clientID, clientSecret string) *int {
+	var issued int
+	th.Mux.HandleFunc("/idp/token", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Content-Type", "application/x-www-form-urlencoded")
+		th.TestHeaderUnset(t, r, "X-Auth-Token")
+		th.TestFormValues(t, r, form)
+
+		if clientSecret != "" {
+			id, secret, ok := r.BasicAuth()
+			th.AssertEquals(t, true, ok)
+			th.AssertEquals(t, clientID, id)
+			th.AssertEquals(t, clientSecret, secret)
+		}
+
+		issued++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "%s", "id_token": "id-token", "token_type": "Bearer", "expires_in": 300}`, IdPAccessToken)
+	})
+	return &issued
+}
+
+// HandleFederatedAuth creates an HTTP handler at the federated
+// authentication endpoint of Keystone, which exchanges IdPAccessToken for
+// UnscopedTokenID.
+func HandleFederatedAuth(t *testing.T) {
+	th.Mux.HandleFunc("/OS-FEDERATION/identity_providers/myidp/protocols/openid/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeaderUnset(t, r, "X-Auth-Token")
+		th.TestHeader(t, r, "Authorization", "Bearer "+IdPAccessToken)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", UnscopedTokenID)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, UnscopedTokenOutput)
+	})
+}
+
+// HandleRescope creates an HTTP handler at `/auth/tokens` which rescopes
+// UnscopedTokenID to ScopedTokenID.
+func HandleRescope(t *testing.T) {
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeaderUnset(t, r, "X-Auth-Token")
+		th.TestJSONRequest(t, r, RescopeRequest)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", ScopedTokenID)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, tokens.TokenOutput)
+	})
+}
@@ -0,0 +1,159 @@
+package testing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/oidc"
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+func TestCreatePassword(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleDiscovery(t)
+	issued := HandleIdPToken(t, map[string]string{
+		"grant_type": "password",
+		"scope":      "openid profile",
+		"username":   "demo",
+		"password":   "secret",
+	}, "openstack", "client-secret")
+	HandleFederatedAuth(t)
+	HandleRescope(t)
+
+	options := oidc.AuthOptions{
+		OIDCAuthOptions: gophercloud.OIDCAuthOptions{
+			IdentityProvider:  "myidp",
+			Protocol:          "openid",
+			GrantType:         gophercloud.OIDCGrantPassword,
+			ClientID:          "openstack",
+			ClientSecret:      "client-secret",
+			DiscoveryEndpoint: th.Endpoint() + "idp/.well-known/openid-configuration",
+		},
+		Username: "demo",
+		Password: "secret",
+		Scope: tokens.Scope{
+			ProjectName: "demo",
+			DomainName:  "Default",
+		},
+	}
+
+	result := oidc.Create(context.TODO(), client.ServiceClient(), options)
+	tokenID, err := result.ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, ScopedTokenID, tokenID)
+
+	project, err := result.ExtractProject()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "admin", project.Name)
+	th.AssertEquals(t, 1, *issued)
+}
+
+func TestCreateClientCredentialsUnscoped(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleIdPToken(t, map[string]string{
+		"grant_type": "client_credentials",
+		"scope":      "openid",
+	}, "openstack", "client-secret")
+	HandleFederatedAuth(t)
+
+	options := oidc.AuthOptions{
+		OIDCAuthOptions: gophercloud.OIDCAuthOptions{
+			IdentityProvider:    "myidp",
+			Protocol:            "openid",
+			GrantType:           gophercloud.OIDCGrantClientCredentials,
+			ClientID:            "openstack",
+			ClientSecret:        "client-secret",
+			AccessTokenEndpoint: th.Endpoint() + "idp/token",
+			Scopes:              []string{"openid"},
+		},
+	}
+
+	result := oidc.Create(context.TODO(), client.ServiceClient(), options)
+	tokenID, err := result.ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, UnscopedTokenID, tokenID)
+
+	user, err := result.ExtractUser()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "demo", user.Name)
+}
+
+func TestCreateAuthorizationCodePublicClient(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleIdPToken(t, map[string]string{
+		"grant_type":   "authorization_code",
+		"scope":        "openid profile",
+		"code":         "auth-code",
+		"redirect_uri": "http://localhost:8080/callback",
+		"client_id":    "openstack",
+	}, "openstack", "")
+	HandleFederatedAuth(t)
+
+	options := oidc.AuthOptions{
+		OIDCAuthOptions: gophercloud.OIDCAuthOptions{
+			IdentityProvider:    "myidp",
+			Protocol:            "openid",
+			GrantType:           gophercloud.OIDCGrantAuthorizationCode,
+			ClientID:            "openstack",
+			AccessTokenEndpoint: th.Endpoint() + "idp/token",
+			AuthorizationCode:   "auth-code",
+			RedirectURI:         "http://localhost:8080/callback",
+		},
+		AllowReauth: true,
+	}
+
+	tokenID, err := oidc.Create(context.TODO(), client.ServiceClient(), options).ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, UnscopedTokenID, tokenID)
+	th.AssertEquals(t, false, options.CanReauth())
+}
+
+func TestCreateAccessToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleFederatedAuth(t)
+	HandleRescope(t)
+
+	options := oidc.AuthOptions{
+		OIDCAuthOptions: gophercloud.OIDCAuthOptions{
+			IdentityProvider: "myidp",
+			Protocol:         "openid",
+			AccessToken:      IdPAccessToken,
+		},
+		Scope: tokens.Scope{
+			ProjectName: "demo",
+			DomainName:  "Default",
+		},
+	}
+
+	tokenID, err := oidc.Create(context.TODO(), client.ServiceClient(), options).ExtractTokenID()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, ScopedTokenID, tokenID)
+}
+
+func TestCreateMissingInput(t *testing.T) {
+	for name, options := range map[string]oidc.AuthOptions{
+		"no identity provider": {OIDCAuthOptions: gophercloud.OIDCAuthOptions{Protocol: "openid", AccessToken: "token"}},
+		"no grant type":        {OIDCAuthOptions: gophercloud.OIDCAuthOptions{IdentityProvider: "myidp", Protocol: "openid"}},
+		"no password": {
+			OIDCAuthOptions: gophercloud.OIDCAuthOptions{IdentityProvider: "myidp", Protocol: "openid", GrantType: gophercloud.OIDCGrantPassword},
+			Username:        "demo",
+		},
+		"no token endpoint": {
+			OIDCAuthOptions: gophercloud.OIDCAuthOptions{IdentityProvider: "myidp", Protocol: "openid", GrantType: gophercloud.OIDCGrantClientCredentials, ClientID: "openstack", ClientSecret: "secret"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := oidc.Create(context.TODO(), client.ServiceClient(), options).Err
+			if _, ok := err.(gophercloud.ErrMissingInput); !ok {
+				t.Errorf("expected ErrMissingInput, got %v", err)
+			}
+		})
+	}
+}
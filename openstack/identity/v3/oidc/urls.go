@@ -0,0 +1,11 @@
+package oidc
+
+import "github.com/gophercloud/gophercloud/v2"
+
+func federatedAuthURL(c *gophercloud.ServiceClient, idp, protocol string) string {
+	return c.ServiceURL("OS-FEDERATION", "identity_providers", idp, "protocols", protocol, "auth")
+}
+
+func tokenURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL("auth", "tokens")
+}
@@ -22,6 +22,42 @@ Example to List Projects
 		fmt.Printf("%+v\n", project)
 	}
 
+Example to Get a Project with its Hierarchy
+
+	getOpts := projects.GetHierarchyOpts{
+		ParentsAsList: true,
+		SubtreeAsList: true,
+	}
+
+	project, err := projects.GetHierarchy(context.TODO(), identityClient, projectID, getOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, parent := range project.Parents {
+		fmt.Printf("%+v\n", parent)
+	}
+
+Example to Build a Project Tree
+
+	allPages, err := projects.List(identityClient, nil).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allProjects, err := projects.ExtractProjects(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	tree := projects.BuildTree(allProjects)
+	for _, root := range tree.Roots {
+		root.Walk(func(node *projects.ProjectNode, depth int) bool {
+			fmt.Printf("%s%s\n", strings.Repeat("  ", depth), node.Project.Name)
+			return true
+		})
+	}
+
 Example to Create a Project
 
 	createOpts := projects.CreateOpts{
@@ -101,6 +101,56 @@ func Get(ctx context.Context, client *gophercloud.ServiceClient, id string) (r G
 	return
 }
 
+// GetHierarchyOptsBuilder allows extensions to add additional parameters to
+// the GetHierarchy request.
+type GetHierarchyOptsBuilder interface {
+	ToProjectGetHierarchyQuery() (string, error)
+}
+
+// GetHierarchyOpts selects which parts of the project hierarchy are returned
+// along with a project. The list and IDs forms of the same direction are
+// mutually exclusive.
+type GetHierarchyOpts struct {
+	// ParentsAsList populates Parents with the ancestors of the project
+	// which the current token has access to.
+	ParentsAsList bool `q:"parents_as_list"`
+
+	// SubtreeAsList populates Subtree with the descendants of the project
+	// which the current token has access to.
+	SubtreeAsList bool `q:"subtree_as_list"`
+
+	// ParentsAsIDs populates ParentIDs with the IDs of all the ancestors of
+	// the project.
+	ParentsAsIDs bool `q:"parents_as_ids"`
+
+	// SubtreeAsIDs populates SubtreeIDs with the IDs of all the descendants
+	// of the project.
+	SubtreeAsIDs bool `q:"subtree_as_ids"`
+}
+
+// ToProjectGetHierarchyQuery formats a GetHierarchyOpts into a query string.
+func (opts GetHierarchyOpts) ToProjectGetHierarchyQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// GetHierarchy retrieves details on a single project, by ID, along with its
+// parents and/or subtree as selected by opts.
+func GetHierarchy(ctx context.Context, client *gophercloud.ServiceClient, id string, opts GetHierarchyOptsBuilder) (r GetResult) {
+	url := getURL(client, id)
+	if opts != nil {
+		query, err := opts.ToProjectGetHierarchyQuery()
+		if err != nil {
+			r.Err = err
+			return
+		}
+		url += query
+	}
+	resp, err := client.Get(ctx, url, &r.Body, nil)
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	return
+}
+
 // CreateOptsBuilder allows extensions to add additional parameters to
 // the Create request.
 type CreateOptsBuilder interface {
@@ -74,13 +74,35 @@ type Project struct {
 
 	// Options are defined options in the API to enable certain features.
 	Options map[Option]any `json:"options,omitempty"`
+
+	// Parents contains the ancestors of the project, nearest first, when
+	// requested with GetHierarchyOpts.ParentsAsList.
+	Parents []Project `json:"-"`
+
+	// Subtree contains the descendants of the project when requested with
+	// GetHierarchyOpts.SubtreeAsList.
+	Subtree []Project `json:"-"`
+
+	// ParentIDs contains the IDs of the ancestors of the project when
+	// requested with GetHierarchyOpts.ParentsAsIDs.
+	ParentIDs IDTree `json:"-"`
+
+	// SubtreeIDs contains the IDs of the descendants of the project when
+	// requested with GetHierarchyOpts.SubtreeAsIDs.
+	SubtreeIDs IDTree `json:"-"`
 }
 
+// IDTree is a nested set of project IDs as returned by the parents_as_ids
+// and subtree_as_ids queries. Leaves map to a nil IDTree.
+type IDTree map[string]IDTree
+
 func (r *Project) UnmarshalJSON(b []byte) error {
 	type tmp Project
 	var s struct {
 		tmp
-		Extra map[string]any `json:"extra"`
+		Extra   map[string]any  `json:"extra"`
+		Parents json.RawMessage `json:"parents"`
+		Subtree json.RawMessage `json:"subtree"`
 	}
 	err := json.Unmarshal(b, &s)
 	if err != nil {
@@ -88,6 +110,15 @@ func (r *Project) UnmarshalJSON(b []byte) error {
 	}
 	*r = Project(s.tmp)
 
+	r.Parents, r.ParentIDs, err = unmarshalHierarchy(s.Parents)
+	if err != nil {
+		return err
+	}
+	r.Subtree, r.SubtreeIDs, err = unmarshalHierarchy(s.Subtree)
+	if err != nil {
+		return err
+	}
+
 	// Collect other fields and bundle them into Extra
 	// but only if a field titled "extra" wasn't sent.
 	if s.Extra != nil {
@@ -106,6 +137,33 @@ func (r *Project) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// unmarshalHierarchy decodes the "parents" or "subtree" attribute of a
+// project, which is a list of projects or a tree of IDs depending on the
+// query that was used.
+func unmarshalHierarchy(b json.RawMessage) ([]Project, IDTree, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil, nil
+	}
+
+	if b[0] == '[' {
+		var list []struct {
+			Project Project `json:"project"`
+		}
+		if err := json.Unmarshal(b, &list); err != nil {
+			return nil, nil, err
+		}
+		projects := make([]Project, len(list))
+		for i, v := range list {
+			projects[i] = v.Project
+		}
+		return projects, nil, nil
+	}
+
+	var ids IDTree
+	err := json.Unmarshal(b, &ids)
+	return nil, ids, err
+}
+
 // ProjectPage is a single page of Project results.
 type ProjectPage struct {
 	pagination.LinkedPageBase
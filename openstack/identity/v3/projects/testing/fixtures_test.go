@@ -382,3 +382,122 @@ func HandleDeleteProjectTagsSuccessfully(t *testing.T) {
 		w.WriteHeader(http.StatusNoContent)
 	})
 }
+
+// GetHierarchyListOutput provides a GetHierarchy result with the parents and
+// subtree as lists.
+const GetHierarchyListOutput = `
+{
+  "project": {
+    "domain_id": "d1",
+    "enabled": true,
+    "id": "p2",
+    "is_domain": false,
+    "name": "Team",
+    "parent_id": "p1",
+    "parents": [
+      {
+        "project": {
+          "domain_id": "d1",
+          "enabled": true,
+          "id": "p1",
+          "is_domain": false,
+          "name": "Department",
+          "parent_id": "d1"
+        }
+      }
+    ],
+    "subtree": [
+      {
+        "project": {
+          "domain_id": "d1",
+          "enabled": true,
+          "id": "p3",
+          "is_domain": false,
+          "name": "Squad",
+          "parent_id": "p2"
+        }
+      }
+    ]
+  }
+}
+`
+
+// GetHierarchyIDsOutput provides a GetHierarchy result with the parents and
+// subtree as IDs.
+const GetHierarchyIDsOutput = `
+{
+  "project": {
+    "domain_id": "d1",
+    "enabled": true,
+    "id": "p2",
+    "is_domain": false,
+    "name": "Team",
+    "parent_id": "p1",
+    "parents": {
+      "p1": {
+        "d1": null
+      }
+    },
+    "subtree": {
+      "p3": null
+    }
+  }
+}
+`
+
+// Department is a Project fixture at the top of a hierarchy.
+var Department = projects.Project{
+	DomainID: "d1",
+	Enabled:  true,
+	ID:       "p1",
+	Name:     "Department",
+	ParentID: "d1",
+	Extra:    make(map[string]any),
+}
+
+// Team is a Project fixture below Department.
+var Team = projects.Project{
+	DomainID: "d1",
+	Enabled:  true,
+	ID:       "p2",
+	Name:     "Team",
+	ParentID: "p1",
+	Extra:    make(map[string]any),
+}
+
+// Squad is a Project fixture below Team.
+var Squad = projects.Project{
+	DomainID: "d1",
+	Enabled:  true,
+	ID:       "p3",
+	Name:     "Squad",
+	ParentID: "p2",
+	Extra:    make(map[string]any),
+}
+
+// HandleGetProjectHierarchySuccessfully creates an HTTP handler at
+// `/projects/p2` on the test handler mux that responds with a single project
+// and its hierarchy, as lists or IDs depending on the query.
+func HandleGetProjectHierarchySuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/projects/p2", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Has("parents_as_ids") {
+			th.TestFormValues(t, r, map[string]string{
+				"parents_as_ids": "true",
+				"subtree_as_ids": "true",
+			})
+			fmt.Fprint(w, GetHierarchyIDsOutput)
+			return
+		}
+		th.TestFormValues(t, r, map[string]string{
+			"parents_as_list": "true",
+			"subtree_as_list": "true",
+		})
+		fmt.Fprint(w, GetHierarchyListOutput)
+	})
+}
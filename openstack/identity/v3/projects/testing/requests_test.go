@@ -168,3 +168,77 @@ func TestDeleteTags(t *testing.T) {
 	err := projects.DeleteTags(context.TODO(), client.ServiceClient(), "966b3c7d36a24facaf20b7e458bf2192").ExtractErr()
 	th.AssertNoErr(t, err)
 }
+
+func TestGetProjectHierarchyAsList(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetProjectHierarchySuccessfully(t)
+
+	getOpts := projects.GetHierarchyOpts{
+		ParentsAsList: true,
+		SubtreeAsList: true,
+	}
+
+	actual, err := projects.GetHierarchy(context.TODO(), client.ServiceClient(), "p2", getOpts).Extract()
+	th.AssertNoErr(t, err)
+
+	expected := Team
+	expected.Parents = []projects.Project{Department}
+	expected.Subtree = []projects.Project{Squad}
+	th.CheckDeepEquals(t, expected, *actual)
+}
+
+func TestGetProjectHierarchyAsIDs(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleGetProjectHierarchySuccessfully(t)
+
+	getOpts := projects.GetHierarchyOpts{
+		ParentsAsIDs: true,
+		SubtreeAsIDs: true,
+	}
+
+	actual, err := projects.GetHierarchy(context.TODO(), client.ServiceClient(), "p2", getOpts).Extract()
+	th.AssertNoErr(t, err)
+
+	expected := Team
+	expected.ParentIDs = projects.IDTree{"p1": {"d1": nil}}
+	expected.SubtreeIDs = projects.IDTree{"p3": nil}
+	th.CheckDeepEquals(t, expected, *actual)
+}
+
+func TestBuildTree(t *testing.T) {
+	other := projects.Project{ID: "p4", DomainID: "d1", ParentID: "missing"}
+	domain := projects.Project{ID: "d2", DomainID: "d2", IsDomain: true}
+
+	tree := projects.BuildTree([]projects.Project{Squad, Team, Department, other, domain})
+
+	th.AssertEquals(t, 3, len(tree.Roots))
+	th.CheckEquals(t, "d1", tree.Roots[0].Project.ID)
+	th.CheckEquals(t, true, tree.Roots[0].Project.IsDomain)
+	th.CheckEquals(t, "p4", tree.Roots[1].Project.ID)
+	th.CheckEquals(t, "d2", tree.Roots[2].Project.ID)
+
+	squadNode := tree.Node("p3")
+	th.AssertEquals(t, true, squadNode != nil)
+	var ancestors []string
+	for _, n := range squadNode.Ancestors() {
+		ancestors = append(ancestors, n.Project.ID)
+	}
+	th.CheckDeepEquals(t, []string{"p2", "p1", "d1"}, ancestors)
+
+	var descendants []string
+	for _, n := range tree.Node("d1").Descendants() {
+		descendants = append(descendants, n.Project.ID)
+	}
+	th.CheckDeepEquals(t, []string{"p1", "p2", "p3"}, descendants)
+
+	depths := make(map[string]int)
+	tree.Node("p1").Walk(func(n *projects.ProjectNode, depth int) bool {
+		depths[n.Project.ID] = depth
+		return n.Project.ID != "p2"
+	})
+	th.CheckDeepEquals(t, map[string]int{"p1": 0, "p2": 1}, depths)
+
+	th.CheckEquals(t, true, tree.Node("missing") == nil)
+}
@@ -0,0 +1,113 @@
+package projects
+
+// ProjectNode is a project within a ProjectTree.
+type ProjectNode struct {
+	// Project is the project at this node. For a domain that was not part of
+	// the projects the tree was built from, only ID and IsDomain are set.
+	Project Project
+
+	// Parent is the parent node, or nil for a root.
+	Parent *ProjectNode
+
+	// Children are the direct children of the node, in the order the
+	// projects were given.
+	Children []*ProjectNode
+}
+
+// Ancestors returns the ancestors of the node, nearest first.
+func (n *ProjectNode) Ancestors() []*ProjectNode {
+	var ancestors []*ProjectNode
+	for p := n.Parent; p != nil; p = p.Parent {
+		ancestors = append(ancestors, p)
+	}
+	return ancestors
+}
+
+// Descendants returns all the descendants of the node, depth first.
+func (n *ProjectNode) Descendants() []*ProjectNode {
+	var descendants []*ProjectNode
+	for _, c := range n.Children {
+		descendants = append(descendants, c)
+		descendants = append(descendants, c.Descendants()...)
+	}
+	return descendants
+}
+
+// Walk calls fn for the node and all its descendants, depth first. The depth
+// is relative to the node Walk was called on. Returning false from fn skips
+// the children of the current node.
+func (n *ProjectNode) Walk(fn func(node *ProjectNode, depth int) bool) {
+	n.walk(fn, 0)
+}
+
+func (n *ProjectNode) walk(fn func(node *ProjectNode, depth int) bool, depth int) {
+	if !fn(n, depth) {
+		return
+	}
+	for _, c := range n.Children {
+		c.walk(fn, depth+1)
+	}
+}
+
+// ProjectTree is a navigable hierarchy of projects, with domains at the
+// root.
+type ProjectTree struct {
+	// Roots are the top-level nodes of the tree. These are domains, and
+	// projects whose parent is neither part of the tree nor their domain.
+	Roots []*ProjectNode
+
+	nodes map[string]*ProjectNode
+}
+
+// Node returns the node of the project with the given ID, or nil if it is
+// not part of the tree.
+func (t *ProjectTree) Node(id string) *ProjectNode {
+	return t.nodes[id]
+}
+
+// BuildTree arranges projects into a ProjectTree according to their ParentID.
+// Domains may be included in projects, e.g. by listing with IsDomain set;
+// otherwise a placeholder node is created for the domain of every top-level
+// project. The result of a GetHierarchy request can be arranged by passing
+// the project together with its Parents and Subtree.
+func BuildTree(projects []Project) *ProjectTree {
+	t := &ProjectTree{
+		nodes: make(map[string]*ProjectNode, len(projects)),
+	}
+
+	ordered := make([]*ProjectNode, 0, len(projects))
+	for _, p := range projects {
+		if _, ok := t.nodes[p.ID]; ok {
+			continue
+		}
+		n := &ProjectNode{Project: p}
+		t.nodes[p.ID] = n
+		ordered = append(ordered, n)
+	}
+
+	for _, n := range ordered {
+		p := n.Project
+		if p.IsDomain || p.ParentID == "" {
+			t.Roots = append(t.Roots, n)
+			continue
+		}
+
+		parent, ok := t.nodes[p.ParentID]
+		if !ok {
+			if p.ParentID != p.DomainID {
+				t.Roots = append(t.Roots, n)
+				continue
+			}
+			parent = &ProjectNode{
+				Project: Project{ID: p.DomainID, IsDomain: true},
+			}
+			t.nodes[p.DomainID] = parent
+			t.Roots = append(t.Roots, parent)
+		}
+
+		n.Parent = parent
+		parent.Children = append(parent.Children, n)
+	}
+
+	return t
+}
@@ -0,0 +1,26 @@
+/*
+Package revokeevents retrieves token revocation events (OS-REVOKE) from the
+OpenStack Identity Service.
+
+Example to List Revocation Events Since a Given Time
+
+	since := time.Now().Add(-time.Hour)
+	listOpts := revokeevents.ListOpts{
+		Since: &since,
+	}
+
+	allPages, err := revokeevents.List(identityClient, listOpts).AllPages(context.TODO())
+	if err != nil {
+		panic(err)
+	}
+
+	allEvents, err := revokeevents.ExtractEvents(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, event := range allEvents {
+		fmt.Printf("%+v\n", event)
+	}
+*/
+package revokeevents
@@ -0,0 +1,52 @@
+package revokeevents
+
+import (
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to
+// the List request
+type ListOptsBuilder interface {
+	ToRevokeEventListQuery() (string, error)
+}
+
+// ListOpts provides options to filter the List results.
+type ListOpts struct {
+	// Since filters the response by events which were issued after the
+	// given time.
+	Since *time.Time `q:"-"`
+}
+
+// ToRevokeEventListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToRevokeEventListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+
+	params := q.Query()
+	if opts.Since != nil {
+		params.Add("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	q.RawQuery = params.Encode()
+
+	return q.String(), nil
+}
+
+// List enumerates the token revocation events.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
+	url := listURL(client)
+	if opts != nil {
+		query, err := opts.ToRevokeEventListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(client, url, func(r pagination.PageResult) pagination.Page {
+		return EventPage{pagination.LinkedPageBase{PageResult: r}}
+	})
+}
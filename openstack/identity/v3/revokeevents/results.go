@@ -0,0 +1,87 @@
+package revokeevents
+
+import (
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/pagination"
+)
+
+// Event is a token revocation event. Tokens matching all of the populated
+// attributes of an event and issued before IssuedBefore are revoked.
+type Event struct {
+	// IssuedBefore is the time before which matching tokens are revoked.
+	IssuedBefore time.Time `json:"issued_before"`
+
+	// RevokedAt is the time at which the event was recorded.
+	RevokedAt time.Time `json:"revoked_at"`
+
+	// ExpiresAt matches tokens with the given expiry time.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// UserID matches tokens issued to the given user.
+	UserID string `json:"user_id"`
+
+	// ProjectID matches tokens scoped to the given project.
+	ProjectID string `json:"project_id"`
+
+	// DomainID matches tokens scoped to the given domain.
+	DomainID string `json:"domain_id"`
+
+	// RoleID matches tokens carrying the given role.
+	RoleID string `json:"role_id"`
+
+	// TrustID matches tokens issued through the given trust.
+	TrustID string `json:"trust_id"`
+
+	// ConsumerID matches tokens issued to the given OAuth1 consumer.
+	ConsumerID string `json:"consumer_id"`
+
+	// AccessTokenID matches tokens issued for the given OAuth1 access token.
+	AccessTokenID string `json:"access_token_id"`
+
+	// AuditID matches the token with the given audit ID.
+	AuditID string `json:"audit_id"`
+
+	// AuditChainID matches all tokens in the given audit chain.
+	AuditChainID string `json:"audit_chain_id"`
+}
+
+// EventPage is a single page of Event results.
+type EventPage struct {
+	pagination.LinkedPageBase
+}
+
+// IsEmpty determines whether or not a page of Events contains any results.
+func (r EventPage) IsEmpty() (bool, error) {
+	if r.StatusCode == 204 {
+		return true, nil
+	}
+
+	events, err := ExtractEvents(r)
+	return len(events) == 0, err
+}
+
+// NextPageURL extracts the "next" link from the links section of the result.
+func (r EventPage) NextPageURL() (string, error) {
+	var s struct {
+		Links struct {
+			Next     string `json:"next"`
+			Previous string `json:"previous"`
+		} `json:"links"`
+	}
+	err := r.ExtractInto(&s)
+	if err != nil {
+		return "", err
+	}
+	return s.Links.Next, err
+}
+
+// ExtractEvents returns a slice of Events contained in a single page of
+// results.
+func ExtractEvents(r pagination.Page) ([]Event, error) {
+	var s struct {
+		Events []Event `json:"events"`
+	}
+	err := (r.(EventPage)).ExtractInto(&s)
+	return s.Events, err
+}
@@ -0,0 +1,2 @@
+// revokeevents unit tests
+package testing
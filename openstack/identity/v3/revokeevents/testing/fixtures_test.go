@@ -0,0 +1,74 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/revokeevents"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+// ListOutput provides a single page of revocation events.
+const ListOutput = `
+{
+    "events": [
+        {
+            "issued_before": "2024-02-27T18:30:59.999999Z",
+            "revoked_at": "2024-02-27T18:31:00.000000Z",
+            "user_id": "f4f2e3",
+            "project_id": "9d3f4a"
+        },
+        {
+            "issued_before": "2024-02-28T10:00:00.000000Z",
+            "revoked_at": "2024-02-28T10:00:01.000000Z",
+            "audit_id": "VcxU2JYqT8OzfUVvrjEITQ",
+            "audit_chain_id": "qNUTIJntTzO1-XUk5STybw"
+        }
+    ],
+    "links": {
+        "self": "http://example.com/identity/v3/OS-REVOKE/events",
+        "previous": null,
+        "next": null
+    }
+}
+`
+
+// FirstEvent is the first event in the List response.
+var FirstEvent = revokeevents.Event{
+	IssuedBefore: time.Date(2024, 2, 27, 18, 30, 59, 999999000, time.UTC),
+	RevokedAt:    time.Date(2024, 2, 27, 18, 31, 0, 0, time.UTC),
+	UserID:       "f4f2e3",
+	ProjectID:    "9d3f4a",
+}
+
+// SecondEvent is the second event in the List response.
+var SecondEvent = revokeevents.Event{
+	IssuedBefore: time.Date(2024, 2, 28, 10, 0, 0, 0, time.UTC),
+	RevokedAt:    time.Date(2024, 2, 28, 10, 0, 1, 0, time.UTC),
+	AuditID:      "VcxU2JYqT8OzfUVvrjEITQ",
+	AuditChainID: "qNUTIJntTzO1-XUk5STybw",
+}
+
+// ExpectedEventsSlice is the slice of events expected to be returned from
+// ListOutput.
+var ExpectedEventsSlice = []revokeevents.Event{FirstEvent, SecondEvent}
+
+// HandleListEventsSuccessfully creates an HTTP handler at `/OS-REVOKE/events`
+// on the test handler mux that responds with a list of two events.
+func HandleListEventsSuccessfully(t *testing.T) {
+	th.Mux.HandleFunc("/OS-REVOKE/events", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestHeader(t, r, "X-Auth-Token", client.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"since": "2024-02-27T00:00:00Z",
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, ListOutput)
+	})
+}
@@ -0,0 +1,43 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/openstack/identity/v3/revokeevents"
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+)
+
+func TestListEvents(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+	HandleListEventsSuccessfully(t)
+
+	since := time.Date(2024, 2, 27, 1, 0, 0, 0, time.FixedZone("CET", 3600))
+	listOpts := revokeevents.ListOpts{
+		Since: &since,
+	}
+
+	count := 0
+	err := revokeevents.List(client.ServiceClient(), listOpts).EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		count++
+
+		actual, err := revokeevents.ExtractEvents(page)
+		th.AssertNoErr(t, err)
+
+		th.CheckDeepEquals(t, ExpectedEventsSlice, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, count, 1)
+}
+
+func TestListEventsQuery(t *testing.T) {
+	query, err := revokeevents.ListOpts{}.ToRevokeEventListQuery()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, "", query)
+}
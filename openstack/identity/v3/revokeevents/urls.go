@@ -0,0 +1,12 @@
+package revokeevents
+
+import "github.com/gophercloud/gophercloud/v2"
+
+const (
+	rootPath   = "OS-REVOKE"
+	eventsPath = "events"
+)
+
+func listURL(client *gophercloud.ServiceClient) string {
+	return client.ServiceURL(rootPath, eventsPath)
+}
@@ -103,5 +103,28 @@ Example to Create a Token from a Username and Password with Project Name Scope
 	if err != nil {
 		panic(err)
 	}
+
+Example to Complete a Multi-Factor Authentication with an Auth Receipt
+
+	authOptions := tokens.AuthOptions{
+		UserID:   "username",
+		Password: "password",
+	}
+
+	token, err := tokens.Create(context.TODO(), identityClient, &authOptions).ExtractToken()
+	var receiptErr tokens.ErrAuthReceiptRequired
+	if errors.As(err, &receiptErr) {
+		// receiptErr.Receipt.RemainingMethods() lists the missing methods,
+		// e.g. [["totp"]].
+		authOptions = tokens.AuthOptions{
+			UserID:      "username",
+			Passcode:    "123456",
+			AuthReceipt: receiptErr.Receipt.ID,
+		}
+		token, err = tokens.Create(context.TODO(), identityClient, &authOptions).ExtractToken()
+	}
+	if err != nil {
+		panic(err)
+	}
 */
 package tokens
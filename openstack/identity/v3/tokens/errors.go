@@ -0,0 +1,80 @@
+package tokens
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/v2"
+)
+
+// AuthReceiptHeader is the header holding the ID of an auth receipt.
+const AuthReceiptHeader = "Openstack-Auth-Receipt"
+
+// ErrAuthReceiptRequired is returned by Create when the user has
+// multi-factor authentication rules that the request only partially
+// satisfied. Keystone then responds with 401 and an auth receipt:
+//
+//	_, err := tokens.Create(context.TODO(), identityClient, &authOptions).ExtractToken()
+//	var receiptErr tokens.ErrAuthReceiptRequired
+//	if errors.As(err, &receiptErr) {
+//		authOptions = tokens.AuthOptions{
+//			UserID:      receiptErr.Receipt.User.ID,
+//			Passcode:    promptPasscode(),
+//			AuthReceipt: receiptErr.Receipt.ID,
+//		}
+//		token, err = tokens.Create(context.TODO(), identityClient, &authOptions).ExtractToken()
+//	}
+type ErrAuthReceiptRequired struct {
+	// Receipt is the auth receipt issued by Keystone.
+	Receipt Receipt
+
+	// ErrOriginal is the 401 response error carrying the receipt.
+	ErrOriginal gophercloud.ErrUnexpectedResponseCode
+}
+
+func (e ErrAuthReceiptRequired) Error() string {
+	rules := make([]string, 0, len(e.Receipt.RequiredAuthMethods))
+	for _, methods := range e.Receipt.RemainingMethods() {
+		rules = append(rules, "["+strings.Join(methods, ", ")+"]")
+	}
+	return fmt.Sprintf(
+		"Additional authentication is required, with one of the following sets of methods: %s",
+		strings.Join(rules, " or "),
+	)
+}
+
+func (e ErrAuthReceiptRequired) Unwrap() error {
+	return e.ErrOriginal
+}
+
+// authReceiptError returns an ErrAuthReceiptRequired if err is a 401 response
+// carrying an auth receipt.
+func authReceiptError(err error) (ErrAuthReceiptRequired, bool) {
+	var respErr gophercloud.ErrUnexpectedResponseCode
+	if !errors.As(err, &respErr) || respErr.Actual != http.StatusUnauthorized {
+		return ErrAuthReceiptRequired{}, false
+	}
+
+	id := respErr.ResponseHeader.Get(AuthReceiptHeader)
+	if id == "" {
+		return ErrAuthReceiptRequired{}, false
+	}
+
+	var s struct {
+		Receipt             Receipt    `json:"receipt"`
+		RequiredAuthMethods [][]string `json:"required_auth_methods"`
+	}
+	if err := json.Unmarshal(respErr.Body, &s); err != nil {
+		return ErrAuthReceiptRequired{}, false
+	}
+	s.Receipt.ID = id
+	s.Receipt.RequiredAuthMethods = s.RequiredAuthMethods
+
+	return ErrAuthReceiptRequired{
+		Receipt:     s.Receipt,
+		ErrOriginal: respErr,
+	}, true
+}
@@ -46,6 +46,11 @@ type AuthOptions struct {
 	// Passcode is used in TOTP authentication method
 	Passcode string `json:"passcode,omitempty"`
 
+	// AuthReceipt is the ID of the auth receipt returned by a previous
+	// attempt, in an ErrAuthReceiptRequired. When set, only the remaining
+	// authentication methods need to be provided.
+	AuthReceipt string `json:"-"`
+
 	// At most one of DomainID and DomainName must be provided if using Username
 	// with Identity V3. Otherwise, either are optional.
 	DomainID   string `json:"-"`
@@ -85,6 +90,7 @@ func (opts *AuthOptions) ToTokenV3CreateMap(scope map[string]any) (map[string]an
 		ApplicationCredentialID:     opts.ApplicationCredentialID,
 		ApplicationCredentialName:   opts.ApplicationCredentialName,
 		ApplicationCredentialSecret: opts.ApplicationCredentialSecret,
+		AuthReceipt:                 opts.AuthReceipt,
 	}
 
 	return gophercloudAuthOpts.ToTokenV3CreateMap(scope)
@@ -104,8 +110,8 @@ func (opts *AuthOptions) ToTokenV3ScopeMap() (map[string]any, error) {
 }
 
 func (opts *AuthOptions) CanReauth() bool {
-	if opts.Passcode != "" {
-		// cannot reauth using TOTP passcode
+	if opts.Passcode != "" || opts.AuthReceipt != "" {
+		// cannot reauth using TOTP passcode or an auth receipt
 		return false
 	}
 
@@ -113,9 +119,13 @@ func (opts *AuthOptions) CanReauth() bool {
 }
 
 // ToTokenV3HeadersMap allows AuthOptions to satisfy the AuthOptionsBuilder
-// interface in the v3 tokens package.
+// interface in the v3 tokens package. It returns the auth receipt header, if
+// any.
 func (opts *AuthOptions) ToTokenV3HeadersMap(map[string]any) (map[string]string, error) {
-	return nil, nil
+	if opts.AuthReceipt == "" {
+		return nil, nil
+	}
+	return map[string]string{AuthReceiptHeader: opts.AuthReceipt}, nil
 }
 
 func subjectTokenHeaders(subjectToken string) map[string]string {
@@ -126,6 +136,9 @@ func subjectTokenHeaders(subjectToken string) map[string]string {
 
 // Create authenticates and either generates a new token, or changes the Scope
 // of an existing token.
+//
+// When the user has multi-factor authentication rules and opts only satisfy
+// some of them, the error of the result is an ErrAuthReceiptRequired.
 func Create(ctx context.Context, c *gophercloud.ServiceClient, opts AuthOptionsBuilder) (r CreateResult) {
 	scope, err := opts.ToTokenV3ScopeMap()
 	if err != nil {
@@ -139,10 +152,25 @@ func Create(ctx context.Context, c *gophercloud.ServiceClient, opts AuthOptionsB
 		return
 	}
 
+	headerOpts := map[string]any{
+		"method": "POST",
+		"url":    tokenURL(c),
+	}
+
+	h, err := opts.ToTokenV3HeadersMap(headerOpts)
+	if err != nil {
+		r.Err = err
+		return
+	}
+
 	resp, err := c.Post(ctx, tokenURL(c), b, &r.Body, &gophercloud.RequestOpts{
+		MoreHeaders: h,
 		OmitHeaders: []string{"X-Auth-Token"},
 	})
 	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	if receiptErr, ok := authReceiptError(r.Err); ok {
+		r.Err = receiptErr
+	}
 	return
 }
 
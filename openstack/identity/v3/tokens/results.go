@@ -1,6 +1,7 @@
 package tokens
 
 import (
+	"slices"
 	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -128,6 +129,16 @@ func (r GetResult) ExtractTokenID() (string, error) {
 	return r.Header.Get("X-Subject-Token"), r.Err
 }
 
+// ExtractExpiresAt implements the gophercloud.ExpiringAuthResult interface. It
+// returns the expiration time of the token.
+func (r commonResult) ExtractExpiresAt() (time.Time, error) {
+	token, err := r.ExtractToken()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return token.ExpiresAt, nil
+}
+
 // ExtractServiceCatalog returns the ServiceCatalog that was generated along
 // with the user's Token.
 func (r commonResult) ExtractServiceCatalog() (*ServiceCatalog, error) {
@@ -213,3 +224,48 @@ type Token struct {
 func (r commonResult) ExtractInto(v any) error {
 	return r.ExtractIntoStructPtr(v, "token")
 }
+
+// Receipt is issued by Keystone when an authentication request of a user with
+// multi-factor authentication rules succeeds with only some of the methods
+// the rules require. Passing its ID as the AuthReceipt of a new request
+// allows completing the authentication with the remaining methods, until the
+// receipt expires.
+type Receipt struct {
+	// ID is the auth receipt.
+	ID string `json:"-"`
+
+	// Methods are the authentication methods that already succeeded.
+	Methods []string `json:"methods"`
+
+	// User is the user being authenticated.
+	User User `json:"user"`
+
+	// ExpiresAt is the timestamp at which the receipt will no longer be
+	// accepted.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// IssuedAt is the timestamp at which the receipt was issued.
+	IssuedAt time.Time `json:"issued_at"`
+
+	// RequiredAuthMethods are the multi-factor authentication rules of the
+	// user. Each rule is a set of methods which together allow to
+	// authenticate.
+	RequiredAuthMethods [][]string `json:"-"`
+}
+
+// RemainingMethods returns, for each of the RequiredAuthMethods, the methods
+// still needed to satisfy it. Completing any of the returned sets is enough
+// to authenticate.
+func (r Receipt) RemainingMethods() [][]string {
+	remaining := make([][]string, 0, len(r.RequiredAuthMethods))
+	for _, rule := range r.RequiredAuthMethods {
+		var methods []string
+		for _, m := range rule {
+			if !slices.Contains(r.Methods, m) {
+				methods = append(methods, m)
+			}
+		}
+		remaining = append(remaining, methods)
+	}
+	return remaining
+}
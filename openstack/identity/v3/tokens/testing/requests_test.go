@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -543,6 +544,125 @@ func TestCreateExtractsTokenFromResponse(t *testing.T) {
 	}
 }
 
+func TestCreateAuthReceiptRequired(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	client := gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+
+		if r.Header.Get(tokens.AuthReceiptHeader) == "" {
+			th.TestJSONRequest(t, r, `
+				{
+					"auth": {
+						"identity": {
+							"methods": ["password"],
+							"password": {
+								"user": {
+									"id": "me",
+									"password": "shhh"
+								}
+							}
+						}
+					}
+				}
+			`)
+
+			w.Header().Add(tokens.AuthReceiptHeader, "receipt-id")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{
+				"receipt": {
+					"expires_at": "2018-07-27T09:22:51.000000Z",
+					"issued_at": "2018-07-27T09:17:51.000000Z",
+					"methods": ["password"],
+					"user": {
+						"domain": {"id": "default", "name": "Default"},
+						"id": "me",
+						"name": "me"
+					}
+				},
+				"required_auth_methods": [
+					["password", "totp"],
+					["password", "custom-auth-method"]
+				]
+			}`)
+			return
+		}
+
+		th.TestHeader(t, r, tokens.AuthReceiptHeader, "receipt-id")
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": {
+						"methods": ["totp"],
+						"totp": {
+							"user": {
+								"id": "me",
+								"passcode": "123456"
+							}
+						}
+					}
+				}
+			}
+		`)
+
+		w.Header().Add("X-Subject-Token", "aaa111")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{
+			"token": {
+				"expires_at": "2014-10-02T13:45:00.000000Z"
+			}
+		}`)
+	})
+
+	options := tokens.AuthOptions{UserID: "me", Password: "shhh"}
+	_, err := tokens.Create(context.TODO(), &client, &options).Extract()
+
+	var receiptErr tokens.ErrAuthReceiptRequired
+	if !errors.As(err, &receiptErr) {
+		t.Fatalf("Create returned an unexpected error: %v", err)
+	}
+	th.AssertEquals(t, true, gophercloud.ResponseCodeIs(err, http.StatusUnauthorized))
+	th.AssertEquals(t, "receipt-id", receiptErr.Receipt.ID)
+	th.AssertEquals(t, "me", receiptErr.Receipt.User.ID)
+	th.AssertEquals(t, time.Date(2018, 7, 27, 9, 22, 51, 0, time.UTC), receiptErr.Receipt.ExpiresAt)
+	th.CheckDeepEquals(t, []string{"password"}, receiptErr.Receipt.Methods)
+	th.CheckDeepEquals(t, [][]string{{"totp"}, {"custom-auth-method"}}, receiptErr.Receipt.RemainingMethods())
+
+	options = tokens.AuthOptions{UserID: "me", Passcode: "123456", AuthReceipt: receiptErr.Receipt.ID}
+	th.AssertEquals(t, false, options.CanReauth())
+	token, err := tokens.Create(context.TODO(), &client, &options).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "aaa111", token.ID)
+}
+
+func TestCreateUnauthorizedWithoutReceipt(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	client := gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{},
+		Endpoint:       th.Endpoint(),
+	}
+
+	th.Mux.HandleFunc("/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	options := tokens.AuthOptions{UserID: "me", Password: "shhh"}
+	_, err := tokens.Create(context.TODO(), &client, &options).Extract()
+
+	var receiptErr tokens.ErrAuthReceiptRequired
+	th.AssertEquals(t, false, errors.As(err, &receiptErr))
+	th.AssertEquals(t, true, gophercloud.ResponseCodeIs(err, http.StatusUnauthorized))
+}
+
 func TestCreateFailureEmptyAuth(t *testing.T) {
 	authTokenPostErr(t, tokens.AuthOptions{}, nil, false, gophercloud.ErrMissingPassword{})
 }
@@ -3,6 +3,7 @@ package images
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"time"
 
@@ -142,6 +143,12 @@ func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 	})
 }
 
+// ListIter returns an iterator over the images returned by List. Pages are
+// fetched lazily, so breaking out of the loop stops further requests.
+func ListIter(ctx context.Context, c *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[Image, error] {
+	return pagination.Items(ctx, List(c, opts), ExtractImages)
+}
+
 // CreateOptsBuilder allows extensions to add parameters to the Create request.
 type CreateOptsBuilder interface {
 	// Returns value that can be passed to json.Marshal
@@ -3,6 +3,7 @@ package ports
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"slices"
 
@@ -99,6 +100,12 @@ func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 	})
 }
 
+// ListIter returns an iterator over the ports returned by List. Pages are
+// fetched lazily, so breaking out of the loop stops further requests.
+func ListIter(ctx context.Context, c *gophercloud.ServiceClient, opts ListOptsBuilder) iter.Seq2[Port, error] {
+	return pagination.Items(ctx, List(c, opts), ExtractPorts)
+}
+
 // Get retrieves a specific port based on its unique ID.
 func Get(ctx context.Context, c *gophercloud.ServiceClient, id string) (r GetResult) {
 	resp, err := c.Get(ctx, getURL(c, id), &r.Body, nil)
@@ -61,6 +61,87 @@ func TestAuthenticatedClientV3(t *testing.T) {
 	th.CheckEquals(t, ID, client.TokenID)
 }
 
+func TestAuthenticatedClientV3OIDC(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `
+			{
+				"versions": {
+					"values": [
+						{
+							"status": "stable",
+							"id": "v3.0",
+							"links": [
+								{ "href": "%s", "rel": "self" }
+							]
+						}
+					]
+				}
+			}
+		`, th.Endpoint()+"v3/")
+	})
+
+	var accessTokens int
+	th.Mux.HandleFunc("/idp/token", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestFormValues(t, r, map[string]string{
+			"grant_type": "client_credentials",
+			"scope":      "openid profile",
+		})
+
+		accessTokens++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "access_token": "access-token-%d" }`, accessTokens)
+	})
+
+	th.Mux.HandleFunc("/v3/OS-FEDERATION/identity_providers/myidp/protocols/openid/auth", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "Authorization", fmt.Sprintf("Bearer access-token-%d", accessTokens))
+
+		w.Header().Add("X-Subject-Token", "unscoped")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{ "token": { "expires_at": "2013-02-02T18:30:59.000000Z" } }`)
+	})
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		th.TestJSONRequest(t, r, `
+			{
+				"auth": {
+					"identity": { "methods": ["token"], "token": { "id": "unscoped" } },
+					"scope": { "project": { "id": "project" } }
+				}
+			}
+		`)
+
+		w.Header().Add("X-Subject-Token", fmt.Sprintf("%s-%d", ID, accessTokens))
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{ "token": { "expires_at": "2013-02-02T18:30:59.000000Z" } }`)
+	})
+
+	options := gophercloud.AuthOptions{
+		IdentityEndpoint: th.Endpoint(),
+		TenantID:         "project",
+		AllowReauth:      true,
+		OIDC: &gophercloud.OIDCAuthOptions{
+			IdentityProvider:    "myidp",
+			Protocol:            "openid",
+			GrantType:           gophercloud.OIDCGrantClientCredentials,
+			ClientID:            "openstack",
+			ClientSecret:        "secret",
+			AccessTokenEndpoint: th.Endpoint() + "idp/token",
+		},
+	}
+	client, err := openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, ID+"-1", client.TokenID)
+
+	err = client.Reauthenticate(context.TODO(), client.TokenID)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, ID+"-2", client.TokenID)
+}
+
 func TestAuthenticatedClientV2(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -0,0 +1,271 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/openstack"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+// handleCachedTokens sets up an identity v3 endpoint issuing tokens valid for
+// an hour, and validating the tokens in valid. It returns the number of
+// issued tokens.
+func handleCachedTokens(t *testing.T, mut *sync.Mutex, valid map[string]bool) *int {
+	issued := new(int)
+
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `
+			{
+				"versions": {
+					"values": [
+						{
+							"status": "stable",
+							"id": "v3.0",
+							"links": [
+								{ "href": "%s", "rel": "self" }
+							]
+						}
+					]
+				}
+			}
+		`, th.Endpoint()+"v3/")
+	})
+
+	th.Mux.HandleFunc("/v3/auth/tokens", func(w http.ResponseWriter, r *http.Request) {
+		mut.Lock()
+		defer mut.Unlock()
+
+		switch r.Method {
+		case "POST":
+			*issued++
+			id := fmt.Sprintf("token-%d", *issued)
+			valid[id] = true
+
+			w.Header().Add("X-Subject-Token", id)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `
+				{
+					"token": {
+						"expires_at": "%s",
+						"catalog": [
+							{
+								"type": "compute",
+								"name": "nova",
+								"endpoints": [
+									{
+										"id": "1",
+										"interface": "public",
+										"region": "RegionOne",
+										"region_id": "RegionOne",
+										"url": "%s"
+									}
+								]
+							}
+						]
+					}
+				}
+			`, time.Now().Add(time.Hour).UTC().Format(gophercloud.RFC3339Milli), th.Endpoint()+"compute/")
+		case "HEAD":
+			th.TestHeader(t, r, "X-Auth-Token", r.Header.Get("X-Subject-Token"))
+			if !valid[r.Header.Get("X-Subject-Token")] {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	return issued
+}
+
+func TestAuthenticateTokenCache(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	mut := new(sync.Mutex)
+	valid := make(map[string]bool)
+	issued := handleCachedTokens(t, mut, valid)
+
+	cache, err := openstack.NewFileTokenCache(t.TempDir())
+	th.AssertNoErr(t, err)
+
+	options := gophercloud.AuthOptions{
+		Username:         "me",
+		Password:         "secret",
+		DomainName:       "default",
+		TenantName:       "project",
+		IdentityEndpoint: th.Endpoint(),
+		TokenCache:       cache,
+	}
+
+	provider, err := openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-1", provider.Token())
+	th.AssertEquals(t, 1, *issued)
+
+	cached, err := cache.Load(context.TODO(), options.TokenCacheKey())
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-1", cached.ID)
+
+	// The cached token is reused, along with its catalog.
+	provider, err = openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-1", provider.Token())
+	th.AssertEquals(t, 1, *issued)
+
+	compute, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{Region: "RegionOne"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, th.Endpoint()+"compute/", compute.Endpoint)
+
+	// A revoked token is replaced.
+	mut.Lock()
+	delete(valid, "token-1")
+	mut.Unlock()
+
+	provider, err = openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-2", provider.Token())
+	th.AssertEquals(t, 2, *issued)
+
+	cached, err = cache.Load(context.TODO(), options.TokenCacheKey())
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-2", cached.ID)
+
+	// A token expiring soon is replaced.
+	cached.ExpiresAt = time.Now().Add(time.Minute)
+	err = cache.Store(context.TODO(), options.TokenCacheKey(), *cached)
+	th.AssertNoErr(t, err)
+
+	provider, err = openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-3", provider.Token())
+	th.AssertEquals(t, 3, *issued)
+}
+
+func TestAuthenticateTokenCachePassword(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	mut := new(sync.Mutex)
+	valid := make(map[string]bool)
+	issued := handleCachedTokens(t, mut, valid)
+
+	cache, err := openstack.NewFileTokenCache(t.TempDir())
+	th.AssertNoErr(t, err)
+
+	options := gophercloud.AuthOptions{
+		Username:         "me",
+		Password:         "secret",
+		DomainName:       "default",
+		IdentityEndpoint: th.Endpoint(),
+		TokenCache:       cache,
+	}
+
+	provider, err := openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-1", provider.Token())
+
+	// The token cached for the right password is not handed out for another
+	// one: Keystone must check it.
+	options.Password = "wrong"
+	provider, err = openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-2", provider.Token())
+	th.AssertEquals(t, 2, *issued)
+}
+
+func TestAuthenticateTokenCachePasscode(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	mut := new(sync.Mutex)
+	valid := make(map[string]bool)
+	issued := handleCachedTokens(t, mut, valid)
+
+	cache, err := openstack.NewFileTokenCache(t.TempDir())
+	th.AssertNoErr(t, err)
+
+	options := gophercloud.AuthOptions{
+		UserID:           "me",
+		Password:         "secret",
+		Passcode:         "123456",
+		IdentityEndpoint: th.Endpoint(),
+		TokenCache:       cache,
+	}
+
+	// Logins with a TOTP passcode skip the cache.
+	_, err = openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, *issued)
+
+	cached, err := cache.Load(context.TODO(), options.TokenCacheKey())
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, (*gophercloud.CachedToken)(nil), cached)
+}
+
+func TestAuthenticateTokenCacheReauth(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	mut := new(sync.Mutex)
+	valid := make(map[string]bool)
+	issued := handleCachedTokens(t, mut, valid)
+
+	cache, err := openstack.NewFileTokenCache(t.TempDir())
+	th.AssertNoErr(t, err)
+
+	options := gophercloud.AuthOptions{
+		UserID:           "me",
+		Password:         "secret",
+		IdentityEndpoint: th.Endpoint(),
+		AllowReauth:      true,
+		TokenCache:       cache,
+	}
+
+	provider, err := openstack.AuthenticatedClient(context.TODO(), options)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, *issued)
+
+	err = provider.Reauthenticate(context.TODO(), "")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-2", provider.Token())
+	th.AssertEquals(t, 2, *issued)
+
+	cached, err := cache.Load(context.TODO(), options.TokenCacheKey())
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "token-2", cached.ID)
+}
+
+func TestFileTokenCache(t *testing.T) {
+	cache, err := openstack.NewFileTokenCache(t.TempDir())
+	th.AssertNoErr(t, err)
+
+	cached, err := cache.Load(context.TODO(), "key")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, (*gophercloud.CachedToken)(nil), cached)
+
+	token := gophercloud.CachedToken{
+		ID:        "token",
+		ExpiresAt: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		Body:      []byte(`{"token":{}}`),
+	}
+	th.AssertNoErr(t, cache.Store(context.TODO(), "key", token))
+
+	cached, err = cache.Load(context.TODO(), "key")
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, token, *cached)
+
+	th.AssertNoErr(t, cache.Delete(context.TODO(), "key"))
+	th.AssertNoErr(t, cache.Delete(context.TODO(), "key"))
+	cached, err = cache.Load(context.TODO(), "key")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, (*gophercloud.CachedToken)(nil), cached)
+}
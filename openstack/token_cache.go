@@ -0,0 +1,228 @@
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	tokens3 "github.com/gophercloud/gophercloud/v2/openstack/identity/v3/tokens"
+)
+
+// defaultTokenCacheMinLifetime is the shortest remaining lifetime of a
+// cached token for it to be reused, when the ProviderClient has no
+// TokenRenewalMargin.
+const defaultTokenCacheMinLifetime = 5 * time.Minute
+
+// FileTokenCache is a gophercloud.TokenCache storing each token in its own
+// file of a directory, readable only by the current user. It can be shared by
+// concurrent processes.
+type FileTokenCache struct {
+	dir string
+}
+
+// NewFileTokenCache returns a FileTokenCache storing the tokens in dir. When
+// dir is empty, the gophercloud/tokens directory of the user cache directory
+// is used, e.g. ~/.cache/gophercloud/tokens on Linux.
+//
+//	ao, err := openstack.AuthOptionsFromEnv()
+//	ao.TokenCache, err = openstack.NewFileTokenCache("")
+//	provider, err := openstack.AuthenticatedClient(ctx, ao)
+func NewFileTokenCache(dir string) (*FileTokenCache, error) {
+	if dir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(cacheDir, "gophercloud", "tokens")
+	}
+	return &FileTokenCache{dir: dir}, nil
+}
+
+func (c *FileTokenCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// Load implements gophercloud.TokenCache.
+func (c *FileTokenCache) Load(_ context.Context, key string) (*gophercloud.CachedToken, error) {
+	b, err := os.ReadFile(c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var token gophercloud.CachedToken
+	if err := json.Unmarshal(b, &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Store implements gophercloud.TokenCache. The file is replaced atomically,
+// so that concurrent processes never read a partial token.
+func (c *FileTokenCache) Store(_ context.Context, key string, token gophercloud.CachedToken) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return err
+	}
+
+	// CreateTemp creates the file with mode 0600.
+	f, err := os.CreateTemp(c.dir, "."+key+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.path(key))
+}
+
+// Delete implements gophercloud.TokenCache.
+func (c *FileTokenCache) Delete(_ context.Context, key string) error {
+	err := os.Remove(c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// v3authCached authenticates with a token of options.TokenCache if possible,
+// and otherwise authenticates with v3auth and caches the new token. Errors of
+// the cache are ignored, so that authentication does not depend on it.
+func v3authCached(ctx context.Context, client *gophercloud.ProviderClient, endpoint string, options *gophercloud.AuthOptions, eo gophercloud.EndpointOpts) error {
+	cache := options.TokenCache
+	key := options.TokenCacheKey()
+
+	if cached, err := cache.Load(ctx, key); err == nil && cached != nil {
+		if err := v3authFromCache(ctx, client, endpoint, options, eo, cached); err == nil {
+			storeTokenOnReauth(client, cache, key)
+			return nil
+		}
+		_ = cache.Delete(ctx, key)
+	}
+
+	if err := v3auth(ctx, client, endpoint, options, eo); err != nil {
+		return err
+	}
+	storeToken(ctx, client, cache, key)
+	storeTokenOnReauth(client, cache, key)
+	return nil
+}
+
+// v3authFromCache sets a cached token on client, after checking that it
+// remains valid for long enough, and that Keystone still accepts it.
+func v3authFromCache(ctx context.Context, client *gophercloud.ProviderClient, endpoint string, options *gophercloud.AuthOptions, eo gophercloud.EndpointOpts, cached *gophercloud.CachedToken) error {
+	minLifetime := client.TokenRenewalMargin
+	if minLifetime <= 0 {
+		minLifetime = defaultTokenCacheMinLifetime
+	}
+	if time.Until(cached.ExpiresAt) < minLifetime {
+		return errors.New("the cached token expires too soon")
+	}
+
+	var result tokens3.CreateResult
+	result.Header = http.Header{"X-Subject-Token": []string{cached.ID}}
+	if err := json.Unmarshal(cached.Body, &result.Body); err != nil {
+		return err
+	}
+	catalog, err := result.ExtractServiceCatalog()
+	if err != nil {
+		return err
+	}
+
+	v3Client, err := NewIdentityV3(client, eo)
+	if err != nil {
+		return err
+	}
+	if endpoint != "" {
+		v3Client.Endpoint = endpoint
+	}
+
+	// Validate the token with itself, which does not require any role.
+	tac := *client
+	tac.ReauthFunc = nil
+	if err := tac.SetTokenAndAuthResult(result); err != nil {
+		return err
+	}
+	v3Client.ProviderClient = &tac
+	ok, err := tokens3.Validate(ctx, v3Client, cached.ID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("the cached token is not valid")
+	}
+
+	if err := client.SetTokenAndAuthResult(result); err != nil {
+		return err
+	}
+
+	var opts tokens3.AuthOptionsBuilder = options
+	if options.OIDC != nil {
+		opts = oidcAuthOptions(options)
+	}
+	if opts.CanReauth() {
+		if err := setV3ReauthFunc(client, endpoint, opts, eo); err != nil {
+			return err
+		}
+	}
+	client.EndpointLocator = func(opts gophercloud.EndpointOpts) (string, error) {
+		return V3EndpointURL(catalog, opts)
+	}
+
+	return nil
+}
+
+// storeToken stores the token of client in cache.
+func storeToken(ctx context.Context, client *gophercloud.ProviderClient, cache gophercloud.TokenCache, key string) {
+	result, ok := client.GetAuthResult().(tokens3.CreateResult)
+	if !ok {
+		return
+	}
+	token, err := result.ExtractToken()
+	if err != nil || token.ID == "" {
+		return
+	}
+	body, err := json.Marshal(result.Body)
+	if err != nil {
+		return
+	}
+	_ = cache.Store(ctx, key, gophercloud.CachedToken{
+		ID:        token.ID,
+		ExpiresAt: token.ExpiresAt,
+		Body:      body,
+	})
+}
+
+// storeTokenOnReauth makes the ReauthFunc of client, if any, cache the new
+// tokens.
+func storeTokenOnReauth(client *gophercloud.ProviderClient, cache gophercloud.TokenCache, key string) {
+	reauth := client.ReauthFunc
+	if reauth == nil {
+		return
+	}
+	client.ReauthFunc = func(ctx context.Context) error {
+		if err := reauth(ctx); err != nil {
+			return err
+		}
+		storeToken(ctx, client, cache, key)
+		return nil
+	}
+}
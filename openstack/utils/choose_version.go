@@ -3,7 +3,6 @@ package utils
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 
 	"github.com/gophercloud/gophercloud/v2"
@@ -124,52 +123,18 @@ type SupportedMicroversions struct {
 
 // GetSupportedMicroversions returns the minimum and maximum microversion that is supported by the ServiceClient Endpoint.
 func GetSupportedMicroversions(ctx context.Context, client *gophercloud.ServiceClient) (SupportedMicroversions, error) {
-	type valueResp struct {
-		ID         string `json:"id"`
-		Status     string `json:"status"`
-		Version    string `json:"version"`
-		MinVersion string `json:"min_version"`
-	}
-
-	type response struct {
-		Version  valueResp   `json:"version"`
-		Versions []valueResp `json:"versions"`
-	}
-	var minVersion, maxVersion string
 	var supportedMicroversions SupportedMicroversions
-	var resp response
-	_, err := client.Get(ctx, client.Endpoint, &resp, &gophercloud.RequestOpts{
-		OkCodes: []int{200, 300},
-	})
-
+	r, err := client.SupportedMicroversions(ctx)
 	if err != nil {
 		return supportedMicroversions, err
 	}
 
-	if len(resp.Versions) > 0 {
-		// We are dealing with an unversioned endpoint
-		// We only handle the case when there is exactly one, and assume it is the correct one
-		if len(resp.Versions) > 1 {
-			return supportedMicroversions, fmt.Errorf("unversioned endpoint with multiple alternatives not supported")
-		}
-		minVersion = resp.Versions[0].MinVersion
-		maxVersion = resp.Versions[0].Version
-	} else {
-		minVersion = resp.Version.MinVersion
-		maxVersion = resp.Version.Version
-	}
-
-	// Return early if the endpoint does not support microversions
-	if minVersion == "" && maxVersion == "" {
-		return supportedMicroversions, fmt.Errorf("microversions not supported by ServiceClient Endpoint")
-	}
-
-	supportedMicroversions.MinMajor, supportedMicroversions.MinMinor, err = ParseMicroversion(minVersion)
+	supportedMicroversions.MinMajor, supportedMicroversions.MinMinor, err = ParseMicroversion(r.Min)
 	if err != nil {
 		return supportedMicroversions, err
 	}
 
-	supportedMicroversions.MaxMajor, supportedMicroversions.MaxMinor, err = ParseMicroversion(maxVersion)
+	supportedMicroversions.MaxMajor, supportedMicroversions.MaxMinor, err = ParseMicroversion(r.Max)
 	if err != nil {
 		return supportedMicroversions, err
 	}
@@ -220,17 +185,5 @@ func (supported SupportedMicroversions) IsSupported(version string) (bool, error
 // ParseMicroversion parses the version major.minor into separate integers major and minor.
 // For example, "2.53" becomes 2 and 53.
 func ParseMicroversion(version string) (major int, minor int, err error) {
-	parts := strings.Split(version, ".")
-	if len(parts) != 2 {
-		return 0, 0, fmt.Errorf("invalid microversion format: %q", version)
-	}
-	major, err = strconv.Atoi(parts[0])
-	if err != nil {
-		return 0, 0, err
-	}
-	minor, err = strconv.Atoi(parts[1])
-	if err != nil {
-		return 0, 0, err
-	}
-	return major, minor, nil
+	return gophercloud.ParseMicroversion(version)
 }
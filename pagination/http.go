@@ -55,9 +55,16 @@ func PageResultFromParsed(resp *http.Response, body any) PageResult {
 
 // Request performs an HTTP request and extracts the http.Response from the result.
 func Request(ctx context.Context, client *gophercloud.ServiceClient, headers map[string]string, url string) (*http.Response, error) {
+	return request(ctx, client, headers, "", url)
+}
+
+// request performs an HTTP request for a page, with the minimum microversion
+// required by the Pager.
+func request(ctx context.Context, client *gophercloud.ServiceClient, headers map[string]string, minMicroversion string, url string) (*http.Response, error) {
 	return client.Get(ctx, url, nil, &gophercloud.RequestOpts{
 		MoreHeaders:      headers,
 		OkCodes:          []int{200, 204, 300},
 		KeepResponseBody: true,
+		MinMicroversion:  minMicroversion,
 	})
 }
@@ -0,0 +1,63 @@
+package pagination
+
+import (
+	"context"
+	"iter"
+)
+
+// Pages returns an iterator over each page returned by a Pager. Iteration
+// stops at the first error, which is yielded together with a nil Page.
+// Breaking out of the loop stops fetching further pages.
+func (p Pager) Pages(ctx context.Context) iter.Seq2[Page, error] {
+	return func(yield func(Page, error) bool) {
+		stopped := false
+		err := p.EachPage(ctx, func(_ context.Context, page Page) (bool, error) {
+			if !yield(page, nil) {
+				stopped = true
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
+
+// Items returns an iterator over every item of every page returned by a
+// Pager. The extract function interprets a single page, and is usually one of
+// the package-level Extract functions, such as servers.ExtractServers.
+//
+// Pages are fetched lazily: breaking out of the loop stops fetching further
+// pages. Iteration stops at the first error, which is yielded together with
+// the zero value of T.
+//
+//	for server, err := range pagination.Items(ctx, servers.List(client, nil), servers.ExtractServers) {
+//		if err != nil {
+//			panic(err)
+//		}
+//		fmt.Println(server.Name)
+//	}
+func Items[T any](ctx context.Context, p Pager, extract func(Page) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for page, err := range p.Pages(ctx) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, err := extract(page)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -48,6 +48,16 @@ type Pager struct {
 
 	// Headers supplies additional HTTP headers to populate on each paged request.
 	Headers map[string]string
+
+	// MinMicroversion is the lowest microversion each paged request requires.
+	// See gophercloud.RequestOpts.MinMicroversion.
+	MinMicroversion string
+
+	// PrefetchPages is the number of pages that EachPage fetches in the
+	// background while the handler is processing the current page. When left
+	// as 0, pages are fetched sequentially: the next request is only issued
+	// after the handler returns.
+	PrefetchPages int
 }
 
 // NewPager constructs a manually-configured pager.
@@ -71,7 +81,7 @@ func (p Pager) WithPageCreator(createPage func(r PageResult) Page) Pager {
 }
 
 func (p Pager) fetchNextPage(ctx context.Context, url string) (Page, error) {
-	resp, err := Request(ctx, p.client, p.Headers, url)
+	resp, err := request(ctx, p.client, p.Headers, p.MinMicroversion, url)
 	if err != nil {
 		return nil, err
 	}
@@ -87,10 +97,16 @@ func (p Pager) fetchNextPage(ctx context.Context, url string) (Page, error) {
 // EachPage iterates over each page returned by a Pager, yielding one at a time
 // to a handler function. Return "false" from the handler to prematurely stop
 // iterating.
+//
+// If PrefetchPages is set, up to that many pages are requested ahead of the
+// handler. The handler is still invoked sequentially and in order.
 func (p Pager) EachPage(ctx context.Context, handler func(context.Context, Page) (bool, error)) error {
 	if p.Err != nil {
 		return p.Err
 	}
+	if p.PrefetchPages > 0 {
+		return p.eachPagePrefetch(ctx, handler)
+	}
 	currentURL := p.initialURL
 	for {
 		var currentPage Page
@@ -133,6 +149,100 @@ func (p Pager) EachPage(ctx context.Context, handler func(context.Context, Page)
 	}
 }
 
+// prefetchedPage is a page, or the error that occurred while fetching it,
+// handed from the prefetching goroutine to EachPage.
+type prefetchedPage struct {
+	page Page
+	err  error
+}
+
+// eachPagePrefetch implements EachPage for a Pager with PrefetchPages set.
+// A background goroutine walks the pages and sends them through a channel
+// buffered so that at most PrefetchPages pages are held ahead of the handler.
+func (p Pager) eachPagePrefetch(ctx context.Context, handler func(context.Context, Page) (bool, error)) error {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan prefetchedPage, p.PrefetchPages-1)
+	go p.prefetch(fetchCtx, pages)
+
+	for fetched := range pages {
+		if fetched.err != nil {
+			return fetched.err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ok, err := handler(ctx, fetched.page)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	// The channel may have been closed because the context was cancelled
+	// before the goroutine could report the error.
+	return ctx.Err()
+}
+
+// prefetch fetches pages into the pages channel until the last page has been
+// sent, an error occurred, or ctx is cancelled. It closes pages when done.
+func (p Pager) prefetch(ctx context.Context, pages chan<- prefetchedPage) {
+	defer close(pages)
+
+	send := func(fetched prefetchedPage) bool {
+		select {
+		case pages <- fetched:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	currentURL := p.initialURL
+	for {
+		var currentPage Page
+
+		// if first page has already been fetched, no need to fetch it again
+		if p.firstPage != nil {
+			currentPage = p.firstPage
+			p.firstPage = nil
+		} else {
+			var err error
+			currentPage, err = p.fetchNextPage(ctx, currentURL)
+			if err != nil {
+				send(prefetchedPage{err: err})
+				return
+			}
+		}
+
+		empty, err := currentPage.IsEmpty()
+		if err != nil {
+			send(prefetchedPage{err: err})
+			return
+		}
+		if empty {
+			return
+		}
+
+		if !send(prefetchedPage{page: currentPage}) {
+			return
+		}
+
+		currentURL, err = currentPage.NextPageURL()
+		if err != nil {
+			send(prefetchedPage{err: err})
+			return
+		}
+		if currentURL == "" {
+			return
+		}
+	}
+}
+
 // AllPages returns all the pages from a `List` operation in a single page,
 // allowing the user to retrieve all the pages at once.
 func (p Pager) AllPages(ctx context.Context) (Page, error) {
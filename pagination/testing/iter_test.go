@@ -0,0 +1,95 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestItemsLinked(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+
+	var actual []int
+	for i, err := range pagination.Items(context.TODO(), pager, ExtractLinkedInts) {
+		th.AssertNoErr(t, err)
+		actual = append(actual, i)
+	}
+
+	th.CheckDeepEquals(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, actual)
+}
+
+func TestItemsStopEarly(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+
+	var actual []int
+	for i, err := range pagination.Items(context.TODO(), pager, ExtractLinkedInts) {
+		th.AssertNoErr(t, err)
+		actual = append(actual, i)
+		if i == 4 {
+			break
+		}
+	}
+
+	th.CheckDeepEquals(t, []int{1, 2, 3, 4}, actual)
+}
+
+func TestItemsExtractError(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+
+	extractErr := errors.New("extract failed")
+	extract := func(pagination.Page) ([]int, error) {
+		return nil, extractErr
+	}
+
+	count := 0
+	for i, err := range pagination.Items(context.TODO(), pager, extract) {
+		count++
+		th.CheckEquals(t, 0, i)
+		th.CheckEquals(t, extractErr, err)
+	}
+	th.CheckEquals(t, 1, count)
+}
+
+func TestItemsRequestError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/page1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	createPage := func(r pagination.PageResult) pagination.Page {
+		return LinkedPageResult{pagination.LinkedPageBase{PageResult: r}}
+	}
+	pager := pagination.NewPager(createClient(), th.Server.URL+"/page1", createPage)
+
+	count := 0
+	for _, err := range pagination.Items(context.TODO(), pager, ExtractLinkedInts) {
+		count++
+		if err == nil {
+			t.Fatal("Expected an error, got none")
+		}
+	}
+	th.CheckEquals(t, 1, count)
+}
+
+func TestPagesLinked(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+
+	pages := 0
+	for page, err := range pager.Pages(context.TODO()) {
+		th.AssertNoErr(t, err)
+		_, ok := page.(LinkedPageResult)
+		th.AssertEquals(t, true, ok)
+		pages++
+	}
+	th.CheckEquals(t, 3, pages)
+}
@@ -0,0 +1,158 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2/pagination"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestEnumerateLinkedPrefetch(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+	pager.PrefetchPages = 2
+
+	var actual []int
+	err := pager.EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		ints, err := ExtractLinkedInts(page)
+		if err != nil {
+			return false, err
+		}
+		actual = append(actual, ints...)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, actual)
+}
+
+func TestAllPagesLinkedPrefetch(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+	pager.PrefetchPages = 1
+
+	page, err := pager.AllPages(context.TODO())
+	th.AssertNoErr(t, err)
+
+	actual, err := ExtractLinkedInts(page)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}, actual)
+}
+
+func TestPrefetchOverlapsHandler(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	page2Requested := make(chan struct{})
+	th.Mux.HandleFunc("/page1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "ints": [1], "links": { "next": "%s/page2" } }`, th.Server.URL)
+	})
+	th.Mux.HandleFunc("/page2", func(w http.ResponseWriter, r *http.Request) {
+		close(page2Requested)
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprint(w, `{ "ints": [2], "links": { "next": null } }`)
+	})
+
+	createPage := func(r pagination.PageResult) pagination.Page {
+		return LinkedPageResult{pagination.LinkedPageBase{PageResult: r}}
+	}
+	pager := pagination.NewPager(createClient(), th.Server.URL+"/page1", createPage)
+	pager.PrefetchPages = 1
+
+	calls := 0
+	err := pager.EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		calls++
+		if calls == 1 {
+			// The second page must be requested while the first one is
+			// still being handled.
+			select {
+			case <-page2Requested:
+			case <-time.After(5 * time.Second):
+				return false, errors.New("second page was not prefetched")
+			}
+		}
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 2, calls)
+}
+
+func TestPrefetchStopEarly(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+	pager.PrefetchPages = 3
+
+	calls := 0
+	err := pager.EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		calls++
+		return false, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, calls)
+}
+
+func TestPrefetchHandlerError(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+	pager.PrefetchPages = 1
+
+	handlerErr := errors.New("handler failed")
+	err := pager.EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		return false, handlerErr
+	})
+	th.CheckEquals(t, handlerErr, err)
+}
+
+func TestPrefetchCancel(t *testing.T) {
+	pager := createLinked()
+	defer th.TeardownHTTP()
+	pager.PrefetchPages = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	err := pager.EachPage(ctx, func(_ context.Context, page pagination.Page) (bool, error) {
+		calls++
+		cancel()
+		return true, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	th.CheckEquals(t, 1, calls)
+}
+
+func TestPrefetchRequestError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/page1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		fmt.Fprintf(w, `{ "ints": [1], "links": { "next": "%s/page2" } }`, th.Server.URL)
+	})
+	th.Mux.HandleFunc("/page2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	createPage := func(r pagination.PageResult) pagination.Page {
+		return LinkedPageResult{pagination.LinkedPageBase{PageResult: r}}
+	}
+	pager := pagination.NewPager(createClient(), th.Server.URL+"/page1", createPage)
+	pager.PrefetchPages = 1
+
+	calls := 0
+	err := pager.EachPage(context.TODO(), func(_ context.Context, page pagination.Page) (bool, error) {
+		calls++
+		return true, nil
+	})
+	if err == nil {
+		t.Fatal("Expected an error, got none")
+	}
+	th.CheckEquals(t, 1, calls)
+}
@@ -7,8 +7,10 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 )
 
 // DefaultUserAgent is the default User-Agent string set in the request header.
@@ -83,11 +85,26 @@ type ProviderClient struct {
 	// authentication functions for different Identity service versions.
 	ReauthFunc func(context.Context) error
 
+	// TokenRenewalMargin enables the proactive renewal of the token. When set,
+	// a request made less than TokenRenewalMargin before the token expires
+	// first reauthenticates with ReauthFunc, instead of failing with a 401 and
+	// being replayed. The expiry is read from the AuthResult, which must be an
+	// ExpiringAuthResult. Renewals are at least TokenRenewalRetryDelay apart,
+	// and stop while Keystone issues tokens whose lifetime is shorter than the
+	// margin. See also RunTokenRenewal.
+	TokenRenewalMargin time.Duration
+
+	// TokenRenewalRetryDelay is the shortest interval between two proactive
+	// renewals of the token, after which a failed renewal is retried. When not
+	// set, defaults to DefaultTokenRenewalRetryDelay.
+	TokenRenewalRetryDelay time.Duration
+
 	// Throwaway determines whether if this client is a throw-away client. It's a copy of user's provider client
 	// with the token and reauth func zeroed. Such client can be used to perform reauthorization.
 	Throwaway bool
 
-	// Retry backoff func is called when rate limited.
+	// Retry backoff func is called when rate limited. It is not called for requests whose RawBody does not
+	// implement io.Seeker, since they cannot be replayed.
 	RetryBackoffFunc RetryBackoffFunc
 
 	// MaxBackoffRetries set the maximum number of backoffs. When not set, defaults to DefaultMaxBackoffRetries
@@ -97,6 +114,21 @@ type ProviderClient struct {
 	// to abort when an error is encountered.
 	RetryFunc RetryFunc
 
+	// Middleware wraps every HTTP request sent by the client, in order. Use it
+	// to add tracing, metrics, request signing or audit logging. See Use.
+	Middleware []Middleware
+
+	// RateLimiters limits the rate of the requests sent by the service clients
+	// of this provider, by service type (e.g. compute, network). A RateLimiter
+	// set on a ServiceClient takes precedence.
+	RateLimiters map[string]*RateLimiter
+
+	// NegotiateMicroversions enables microversion negotiation for the service clients of this provider.
+	// When a request declares a MinMicroversion higher than the Microversion of its service client,
+	// the range of microversions supported by the endpoint is discovered, cached, and the request is
+	// sent with the lowest suitable microversion instead of failing.
+	NegotiateMicroversions bool
+
 	// mut is a mutex for the client. It protects read and write access to client attributes such as getting
 	// and setting the TokenID.
 	mut *sync.RWMutex
@@ -106,6 +138,20 @@ type ProviderClient struct {
 	reauthmut *reauthlock
 
 	authResult AuthResult
+
+	// tokenExpiresAt is the expiry of the token of authResult, which is parsed
+	// once when it is set rather than on every request. It is zero when
+	// unknown.
+	tokenExpiresAt time.Time
+
+	// tokenRenewedAt is the time of the last renewal attempt made because
+	// of the TokenRenewalMargin. shortLivedTokens is set when such a renewal
+	// returned a token which already expired within the margin.
+	tokenRenewedAt   time.Time
+	shortLivedTokens bool
+
+	// microversions caches the microversions supported by service endpoints, by endpoint URL.
+	microversions map[string]MicroversionRange
 }
 
 // reauthlock represents a set of attributes used to help in the reauthentication process.
@@ -203,6 +249,7 @@ func (client *ProviderClient) SetToken(t string) {
 	}
 	client.TokenID = t
 	client.authResult = nil
+	client.tokenExpiresAt = time.Time{}
 }
 
 // SetTokenAndAuthResult safely sets the value of the auth token in the
@@ -217,6 +264,7 @@ func (client *ProviderClient) SetTokenAndAuthResult(r AuthResult) error {
 			return err
 		}
 	}
+	expiresAt := authResultExpiresAt(r)
 
 	if client.mut != nil {
 		client.mut.Lock()
@@ -224,6 +272,7 @@ func (client *ProviderClient) SetTokenAndAuthResult(r AuthResult) error {
 	}
 	client.TokenID = tokenID
 	client.authResult = r
+	client.tokenExpiresAt = expiresAt
 	return nil
 }
 
@@ -240,6 +289,7 @@ func (client *ProviderClient) CopyTokenFrom(other *ProviderClient) {
 	}
 	client.TokenID = other.TokenID
 	client.authResult = other.authResult
+	client.tokenExpiresAt = other.tokenExpiresAt
 }
 
 // IsThrowaway safely reads the value of the client Throwaway field.
@@ -325,11 +375,24 @@ type RequestOpts struct {
 	// MoreHeaders will be overridden by OmitHeaders
 	MoreHeaders map[string]string
 	// OmitHeaders specifies the HTTP headers which should be omitted.
-	// OmitHeaders will override MoreHeaders
+	// OmitHeaders will override MoreHeaders, and the authentication headers
+	// such as X-Auth-Token
 	OmitHeaders []string
 	// KeepResponseBody specifies whether to keep the HTTP response body. Usually used, when the HTTP
 	// response body is considered for further use. Valid when JSONResponse is nil.
 	KeepResponseBody bool
+	// MinMicroversion is the lowest microversion the request can be sent with. It is only honored
+	// by ServiceClient.Request: if the service client is set to a lower microversion, the request
+	// fails with ErrMicroversionNotSupported, unless the provider has NegotiateMicroversions set.
+	MinMicroversion string
+
+	// serviceType and microversion are set by ServiceClient.Request, so that
+	// they can be reported to Middleware.
+	serviceType  string
+	microversion string
+	// rateLimiter is set by ServiceClient.Request. It is waited on before
+	// each attempt of the request, including retries.
+	rateLimiter *RateLimiter
 }
 
 // requestState contains temporary state for a single ProviderClient.Request() call.
@@ -341,6 +404,20 @@ type requestState struct {
 	hasReauthenticated bool
 	// Retry-After backoff counter, increments during each backoff call
 	retries uint
+	// rawBodyOffset is the offset of options.RawBody when the request was first issued. The body is
+	// rewound to this offset before the request is replayed, if it implements io.Seeker.
+	rawBodyOffset int64
+}
+
+// rewindRawBody rewinds options.RawBody before a request is replayed. Bodies that do not implement
+// io.Seeker are left as they are.
+func (state *requestState) rewindRawBody(options *RequestOpts) error {
+	if seeker, ok := options.RawBody.(io.Seeker); ok {
+		if _, err := seeker.Seek(state.rawBodyOffset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 var applicationJSON = "application/json"
@@ -348,9 +425,18 @@ var applicationJSON = "application/json"
 // Request performs an HTTP request using the ProviderClient's
 // current HTTPClient. An authentication header will automatically be provided.
 func (client *ProviderClient) Request(ctx context.Context, method, url string, options *RequestOpts) (*http.Response, error) {
-	return client.doRequest(ctx, method, url, options, &requestState{
+	state := &requestState{
 		hasReauthenticated: false,
-	})
+	}
+	if seeker, ok := options.RawBody.(io.Seeker); ok {
+		if offset, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			state.rawBodyOffset = offset
+		}
+	}
+	if !slices.ContainsFunc(options.OmitHeaders, func(h string) bool { return strings.EqualFold(h, "X-Auth-Token") }) {
+		client.renewExpiringToken(ctx)
+	}
+	return client.doRequest(ctx, method, url, options, state)
 }
 
 func (client *ProviderClient) doRequest(ctx context.Context, method, url string, options *RequestOpts, state *requestState) (*http.Response, error) {
@@ -398,6 +484,11 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 	// Set the User-Agent header
 	req.Header.Set("User-Agent", client.UserAgent.Join())
 
+	// Propagate the global request ID, if any
+	if id := GlobalRequestIDFromContext(ctx); id != "" {
+		req.Header.Set(GlobalRequestIDHeader, id)
+	}
+
 	if options.MoreHeaders != nil {
 		for k, v := range options.MoreHeaders {
 			req.Header.Set(k, v)
@@ -408,15 +499,30 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 		req.Header.Del(v)
 	}
 
-	// get latest token from client
+	// get latest token from client, unless the caller omitted it
 	for k, v := range client.AuthenticatedHeaders() {
+		if slices.ContainsFunc(options.OmitHeaders, func(h string) bool { return strings.EqualFold(h, k) }) {
+			continue
+		}
 		req.Header.Set(k, v)
 	}
 
 	prereqtok := req.Header.Get("X-Auth-Token")
 
+	if err := options.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
 	// Issue the request.
-	resp, err := client.HTTPClient.Do(req)
+	resp, err := client.send(req, &RequestInfo{
+		Method:          method,
+		URL:             url,
+		Options:         options,
+		ServiceType:     options.serviceType,
+		Microversion:    options.microversion,
+		Retries:         state.retries,
+		Reauthenticated: state.hasReauthenticated,
+	})
 	if err != nil {
 		if client.RetryFunc != nil {
 			var e error
@@ -425,6 +531,9 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 			if e != nil {
 				return nil, e
 			}
+			if err := state.rewindRawBody(options); err != nil {
+				return nil, err
+			}
 
 			return client.doRequest(ctx, method, url, options, state)
 		}
@@ -468,12 +577,8 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 					e.ErrReauth = err
 					return nil, e
 				}
-				if options.RawBody != nil {
-					if seeker, ok := options.RawBody.(io.Seeker); ok {
-						if _, err := seeker.Seek(0, 0); err != nil {
-							return nil, err
-						}
-					}
+				if err := state.rewindRawBody(options); err != nil {
+					return nil, err
 				}
 				state.hasReauthenticated = true
 				resp, err = client.doRequest(ctx, method, url, options, state)
@@ -497,7 +602,8 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 				maxTries = DefaultMaxBackoffRetries
 			}
 
-			if f := client.RetryBackoffFunc; f != nil && state.retries < maxTries {
+			// A RawBody which cannot be rewound has been consumed, so the request cannot be replayed.
+			if f := client.RetryBackoffFunc; f != nil && state.retries < maxTries && canReplayBody(options) {
 				var e error
 
 				state.retries = state.retries + 1
@@ -506,6 +612,9 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 				if e != nil {
 					return resp, e
 				}
+				if err := state.rewindRawBody(options); err != nil {
+					return nil, err
+				}
 
 				return client.doRequest(ctx, method, url, options, state)
 			}
@@ -522,6 +631,9 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 			if e != nil {
 				return resp, e
 			}
+			if err := state.rewindRawBody(options); err != nil {
+				return nil, err
+			}
 
 			return client.doRequest(ctx, method, url, options, state)
 		}
@@ -546,6 +658,9 @@ func (client *ProviderClient) doRequest(ctx context.Context, method, url string,
 				if e != nil {
 					return resp, e
 				}
+				if err := state.rewindRawBody(options); err != nil {
+					return nil, err
+				}
 
 				return client.doRequest(ctx, method, url, options, state)
 			}
@@ -0,0 +1,89 @@
+package gophercloud
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket that limits the rate of requests sent to a
+// service. It holds up to Burst tokens, and is refilled at QPS tokens per
+// second. Each request takes one token, and waits for one to become available
+// when the bucket is empty.
+//
+// A RateLimiter can be set on a ServiceClient, or per service type on a
+// ProviderClient. It is safe for concurrent use, and can be shared between
+// clients to apply a common limit to all of them.
+type RateLimiter struct {
+	qps   float64
+	burst float64
+
+	mut    sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing qps requests per second, with
+// bursts of up to burst requests. The bucket starts full. A burst lower than 1
+// is treated as 1. A RateLimiter with a qps of 0 or less does not limit
+// requests.
+func NewRateLimiter(qps float64, burst int) *RateLimiter {
+	b := float64(max(burst, 1))
+	return &RateLimiter{
+		qps:    qps,
+		burst:  b,
+		tokens: b,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available, and takes it. It returns the
+// context's error if the context is done before that.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.qps <= 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Reserve a token, which may put the bucket in debt, and wait until the
+	// debt is paid off.
+	l.mut.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.qps)
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.qps * float64(time.Second))
+	l.mut.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back.
+		l.mut.Lock()
+		l.tokens = min(l.burst, l.tokens+1)
+		l.mut.Unlock()
+		return ctx.Err()
+	}
+}
+
+// rateLimiter returns the RateLimiter that applies to the requests of the
+// service client: its own, or the one set for its type on the provider.
+func (client *ServiceClient) rateLimiter() *RateLimiter {
+	if client.RateLimiter != nil {
+		return client.RateLimiter
+	}
+	if client.ProviderClient != nil && client.Type != "" {
+		return client.RateLimiters[client.Type]
+	}
+	return nil
+}
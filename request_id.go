@@ -0,0 +1,98 @@
+package gophercloud
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// GlobalRequestIDHeader is the header used to pass a global request ID to
+// OpenStack services, so that the calls they make to each other on behalf of
+// a request can be correlated.
+const GlobalRequestIDHeader = "X-OpenStack-Request-ID"
+
+// globalRequestIDPattern is the format of the global request IDs accepted by
+// the oslo.middleware request_id filter. Other values are ignored by services.
+var globalRequestIDPattern = regexp.MustCompile(`^req-[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}$`)
+
+type globalRequestIDKey struct{}
+
+// NewGlobalRequestID returns a random global request ID, in the
+// "req-<UUID>" format expected by OpenStack services.
+func NewGlobalRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	// Set the version (4) and the variant (RFC 4122) bits.
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("req-%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// WithGlobalRequestID returns a copy of ctx carrying a global request ID. Every
+// request issued by a ProviderClient with the returned context sends it in the
+// X-OpenStack-Request-ID header:
+//
+//	ctx, err := gophercloud.WithGlobalRequestID(context.TODO(), gophercloud.NewGlobalRequestID())
+//	if err != nil {
+//		panic(err)
+//	}
+//	server, err := servers.Create(ctx, computeClient, createOpts, nil).Extract()
+//
+// An error is returned if id is not in the "req-<UUID>" format, which
+// services require.
+func WithGlobalRequestID(ctx context.Context, id string) (context.Context, error) {
+	if !globalRequestIDPattern.MatchString(id) {
+		return ctx, ErrInvalidInput{
+			ErrMissingInput: ErrMissingInput{Argument: "id"},
+			Value:           id,
+		}
+	}
+	return context.WithValue(ctx, globalRequestIDKey{}, id), nil
+}
+
+// GlobalRequestIDFromContext returns the global request ID carried by ctx, or
+// an empty string if there is none.
+func GlobalRequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(globalRequestIDKey{}).(string)
+	return id
+}
+
+// RequestID returns the ID that the service assigned to the request, which
+// identifies it in the service logs. It is read from the
+// X-Openstack-Request-Id or X-Compute-Request-Id response header, and is
+// empty if the service did not send one.
+func (r Result) RequestID() string {
+	if id := requestIDFromHeader(r.Header); id != "" {
+		return id
+	}
+	return RequestIDFromError(r.Err)
+}
+
+// RequestID returns the ID that the service assigned to the failed request.
+func (e ErrUnexpectedResponseCode) RequestID() string {
+	return requestIDFromHeader(e.ResponseHeader)
+}
+
+// RequestIDFromError returns the ID that the service assigned to the request
+// that failed with err, if err is or contains an ErrUnexpectedResponseCode,
+// including as the original error of a reauthentication error. It is safe to
+// pass a nil error, in which case it returns an empty string.
+func RequestIDFromError(err error) string {
+	var codeError ErrUnexpectedResponseCode
+	if errors.As(err, &codeError) {
+		return codeError.RequestID()
+	}
+
+	// The reauthentication errors do not unwrap to the error of the request.
+	var afterReauth *ErrErrorAfterReauthentication
+	if errors.As(err, &afterReauth) {
+		return RequestIDFromError(afterReauth.ErrOriginal)
+	}
+	var unableToReauth *ErrUnableToReauthenticate
+	if errors.As(err, &unableToReauth) {
+		return RequestIDFromError(unableToReauth.ErrOriginal)
+	}
+	return ""
+}
@@ -0,0 +1,218 @@
+package gophercloud
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultRetryPolicyMaxRetries is the number of retries performed by a
+	// RetryPolicy when MaxRetries is not set.
+	DefaultRetryPolicyMaxRetries = 3
+
+	// DefaultRetryPolicyBaseDelay is the delay before the first retry of a
+	// RetryPolicy when BaseDelay is not set.
+	DefaultRetryPolicyBaseDelay = 500 * time.Millisecond
+
+	// DefaultRetryPolicyMaxDelay is the longest delay between two retries of
+	// a RetryPolicy when MaxDelay is not set.
+	DefaultRetryPolicyMaxDelay = 30 * time.Second
+)
+
+// DefaultRetryPolicyStatusCodes are the HTTP status codes retried by a
+// RetryPolicy when StatusCodes is not set.
+var DefaultRetryPolicyStatusCodes = []int{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryPolicy retries failed requests with a jittered exponential backoff.
+// Its Retry method can be used as the RetryFunc of a ProviderClient, and its
+// Backoff method as the RetryBackoffFunc:
+//
+//	policy := gophercloud.RetryPolicy{MaxRetries: 5}
+//	provider.RetryFunc = policy.Retry
+//	provider.RetryBackoffFunc = policy.Backoff
+//
+// Connection errors and the responses listed in StatusCodes are retried. When
+// the response carries a Retry-After header, it is honored instead of the
+// computed delay. Requests with a non-idempotent method (POST and PATCH) are
+// only retried when RetryNonIdempotent is set, and requests with a RawBody
+// are only retried when it implements io.Seeker, so that it can be rewound.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a single request.
+	// Defaults to DefaultRetryPolicyMaxRetries.
+	MaxRetries uint
+
+	// BaseDelay is the delay before the first retry. It doubles with each
+	// retry. Defaults to DefaultRetryPolicyBaseDelay.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two retries, including the one requested
+	// by a Retry-After header. Defaults to DefaultRetryPolicyMaxDelay.
+	MaxDelay time.Duration
+
+	// StatusCodes are the HTTP status codes that are retried. Defaults to
+	// DefaultRetryPolicyStatusCodes.
+	StatusCodes []int
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests. These may
+	// have been processed by the server even though they failed, so retrying
+	// them can, for example, create a resource twice.
+	RetryNonIdempotent bool
+}
+
+// Retry implements RetryFunc. It returns nil after waiting for the backoff
+// delay if the request should be retried, and err otherwise.
+func (p RetryPolicy) Retry(ctx context.Context, method, url string, options *RequestOpts, err error, failCount uint) error {
+	if failCount > p.maxRetries() || !p.canReplay(method, options) {
+		return err
+	}
+
+	var respErr ErrUnexpectedResponseCode
+	switch {
+	case errors.As(err, &respErr):
+		if !slices.Contains(p.statusCodes(), respErr.Actual) {
+			return err
+		}
+		return p.wait(ctx, respErr.ResponseHeader, err, failCount)
+	case isConnectionError(err):
+		return p.wait(ctx, nil, err, failCount)
+	default:
+		return err
+	}
+}
+
+// Backoff implements RetryBackoffFunc, which is called when a request is rate
+// limited. It returns nil after waiting for the backoff delay. A rate limited
+// request has not been processed, so it is retried whatever its method. When
+// it gives up, the error is the ErrUnexpectedResponseCode of the response.
+func (p RetryPolicy) Backoff(ctx context.Context, respErr *ErrUnexpectedResponseCode, err error, retries uint) error {
+	if err == nil && respErr != nil {
+		err = *respErr
+	}
+	if retries > p.maxRetries() {
+		return err
+	}
+
+	var header http.Header
+	if respErr != nil {
+		header = respErr.ResponseHeader
+	}
+	return p.wait(ctx, header, err, retries)
+}
+
+// Delay returns the time to wait before the given retry, which starts at 1.
+// The delay is chosen at random between half and all of the exponential
+// backoff delay, so that clients failing at the same time do not retry at
+// the same time.
+func (p RetryPolicy) Delay(retry uint) time.Duration {
+	maxDelay := p.maxDelay()
+	delay := p.baseDelay()
+	for i := uint(1); i < retry && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func (p RetryPolicy) wait(ctx context.Context, header http.Header, err error, retry uint) error {
+	delay, ok := parseRetryAfter(header.Get("Retry-After"))
+	if ok {
+		delay = min(delay, p.maxDelay())
+	} else {
+		delay = p.Delay(retry)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return err
+	}
+}
+
+// canReplay reports whether a request can be sent again.
+func (p RetryPolicy) canReplay(method string, options *RequestOpts) bool {
+	if !p.RetryNonIdempotent && (method == http.MethodPost || method == http.MethodPatch) {
+		return false
+	}
+	return canReplayBody(options)
+}
+
+// canReplayBody reports whether the RawBody of a request, if any, can be
+// rewound so that the request can be sent again.
+func canReplayBody(options *RequestOpts) bool {
+	if options != nil && options.RawBody != nil {
+		if _, ok := options.RawBody.(io.Seeker); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (p RetryPolicy) maxRetries() uint {
+	if p.MaxRetries == 0 {
+		return DefaultRetryPolicyMaxRetries
+	}
+	return p.MaxRetries
+}
+
+func (p RetryPolicy) baseDelay() time.Duration {
+	if p.BaseDelay <= 0 {
+		return DefaultRetryPolicyBaseDelay
+	}
+	return p.BaseDelay
+}
+
+func (p RetryPolicy) maxDelay() time.Duration {
+	if p.MaxDelay <= 0 {
+		return DefaultRetryPolicyMaxDelay
+	}
+	return p.MaxDelay
+}
+
+func (p RetryPolicy) statusCodes() []int {
+	if p.StatusCodes == nil {
+		return DefaultRetryPolicyStatusCodes
+	}
+	return p.StatusCodes
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(v); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// isConnectionError reports whether err was returned by the HTTP client
+// because the request could not be sent or its response could not be read.
+// Errors caused by the cancellation of the request context are not.
+func isConnectionError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
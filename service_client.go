@@ -33,6 +33,10 @@ type ServiceClient struct {
 	// MoreHeaders allows users (or Gophercloud) to set service-wide headers on requests. Put another way,
 	// values set in this field will be set on all the HTTP requests the service client sends.
 	MoreHeaders map[string]string
+
+	// RateLimiter limits the rate of the requests sent by this service client. When nil, the
+	// RateLimiter set for the service type in ProviderClient.RateLimiters is used, if any.
+	RateLimiter *RateLimiter
 }
 
 // ResourceBaseURL returns the base URL of any resources used by this service. It MUST end with a /.
@@ -114,26 +118,26 @@ func (client *ServiceClient) Head(ctx context.Context, url string, opts *Request
 	return client.Request(ctx, "HEAD", url, opts)
 }
 
-func (client *ServiceClient) setMicroversionHeader(opts *RequestOpts) {
+func (client *ServiceClient) setMicroversionHeader(opts *RequestOpts, microversion string) {
 	serviceType := client.Type
 
 	switch client.Type {
 	case "compute":
-		opts.MoreHeaders["X-OpenStack-Nova-API-Version"] = client.Microversion
+		opts.MoreHeaders["X-OpenStack-Nova-API-Version"] = microversion
 	case "shared-file-system", "sharev2", "share":
-		opts.MoreHeaders["X-OpenStack-Manila-API-Version"] = client.Microversion
+		opts.MoreHeaders["X-OpenStack-Manila-API-Version"] = microversion
 	case "block-storage", "block-store", "volume", "volumev3":
-		opts.MoreHeaders["X-OpenStack-Volume-API-Version"] = client.Microversion
+		opts.MoreHeaders["X-OpenStack-Volume-API-Version"] = microversion
 		// cinder should accept block-storage but (as of Dalmatian) does not
 		serviceType = "volume"
 	case "baremetal":
-		opts.MoreHeaders["X-OpenStack-Ironic-API-Version"] = client.Microversion
+		opts.MoreHeaders["X-OpenStack-Ironic-API-Version"] = microversion
 	case "baremetal-introspection":
-		opts.MoreHeaders["X-OpenStack-Ironic-Inspector-API-Version"] = client.Microversion
+		opts.MoreHeaders["X-OpenStack-Ironic-Inspector-API-Version"] = microversion
 	}
 
 	if client.Type != "" {
-		opts.MoreHeaders["OpenStack-API-Version"] = serviceType + " " + client.Microversion
+		opts.MoreHeaders["OpenStack-API-Version"] = serviceType + " " + microversion
 	}
 }
 
@@ -143,10 +147,18 @@ func (client *ServiceClient) Request(ctx context.Context, method, url string, op
 		options.MoreHeaders = make(map[string]string)
 	}
 
-	if client.Microversion != "" {
-		client.setMicroversionHeader(options)
+	microversion, err := client.requestMicroversion(ctx, options.MinMicroversion)
+	if err != nil {
+		return nil, err
+	}
+	if microversion != "" {
+		client.setMicroversionHeader(options, microversion)
 	}
 
+	options.serviceType = client.Type
+	options.microversion = microversion
+	options.rateLimiter = client.rateLimiter()
+
 	if len(client.MoreHeaders) > 0 {
 		if options == nil {
 			options = new(RequestOpts)
@@ -0,0 +1,39 @@
+/*
+Package telemetry provides OpenTelemetry tracing and metrics for the requests
+issued by a ProviderClient.
+
+Every HTTP request, including each retry and the replay that follows a
+reauthentication, is recorded as one client span. Spans are named after the
+OpenStack service type and the HTTP method (e.g. "compute GET"), and carry the
+service type, the microversion, the HTTP method, the response status, the
+retry count and a "reauthenticated" event when applicable.
+
+Request latency is recorded in the "openstack.client.request.duration"
+histogram, and failed requests are counted in
+"openstack.client.request.errors". Both are broken down by service type, HTTP
+method and status code.
+
+Example to Instrument a ProviderClient
+
+	providerClient, err := openstack.NewClient(authOptions.IdentityEndpoint)
+	if err != nil {
+		panic(err)
+	}
+
+	err = telemetry.Instrument(providerClient,
+		telemetry.WithTracerProvider(tracerProvider),
+		telemetry.WithMeterProvider(meterProvider),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	err = openstack.Authenticate(context.TODO(), providerClient, authOptions)
+	if err != nil {
+		panic(err)
+	}
+
+When no provider is given, the global ones registered with the otel package
+are used.
+*/
+package telemetry
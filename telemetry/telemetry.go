@@ -0,0 +1,182 @@
+package telemetry
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name used for the tracer and the
+// meter.
+const ScopeName = "github.com/gophercloud/gophercloud/v2/telemetry"
+
+// Attribute keys set on spans and metrics.
+const (
+	ServiceTypeKey     = attribute.Key("openstack.service.type")
+	MicroversionKey    = attribute.Key("openstack.microversion")
+	RetryCountKey      = attribute.Key("openstack.retry_count")
+	ReauthenticatedKey = attribute.Key("openstack.reauthenticated")
+	MethodKey          = attribute.Key("http.request.method")
+	StatusCodeKey      = attribute.Key("http.response.status_code")
+	URLKey             = attribute.Key("url.full")
+	ErrorTypeKey       = attribute.Key("error.type")
+)
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagator     propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets the TracerProvider used to create spans. Defaults
+// to the global TracerProvider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to create instruments.
+// Defaults to the global MeterProvider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagator sets the propagator used to inject the span context into the
+// request headers. Defaults to the global TextMapPropagator.
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = p
+	}
+}
+
+// Instrument adds the Middleware returned by NewMiddleware to client.
+func Instrument(client *gophercloud.ProviderClient, opts ...Option) error {
+	m, err := NewMiddleware(opts...)
+	if err != nil {
+		return err
+	}
+	client.Use(m)
+	return nil
+}
+
+// NewMiddleware returns a gophercloud.Middleware that records a span and
+// metrics for every HTTP request sent by a ProviderClient.
+func NewMiddleware(opts ...Option) (gophercloud.Middleware, error) {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagator:     otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	duration, err := meter.Float64Histogram("openstack.client.request.duration",
+		metric.WithDescription("Duration of the HTTP requests sent to OpenStack services."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	errorCount, err := meter.Int64Counter("openstack.client.request.errors",
+		metric.WithDescription("Number of HTTP requests sent to OpenStack services that failed."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &instrumentation{
+		tracer:     c.tracerProvider.Tracer(ScopeName),
+		propagator: c.propagator,
+		duration:   duration,
+		errorCount: errorCount,
+	}
+	return i.middleware, nil
+}
+
+type instrumentation struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	duration   metric.Float64Histogram
+	errorCount metric.Int64Counter
+}
+
+func (i *instrumentation) middleware(next gophercloud.RequestFunc) gophercloud.RequestFunc {
+	return func(req *http.Request, info *gophercloud.RequestInfo) (*http.Response, error) {
+		serviceType := info.ServiceType
+		if serviceType == "" {
+			serviceType = "unknown"
+		}
+
+		attrs := []attribute.KeyValue{
+			ServiceTypeKey.String(serviceType),
+			MethodKey.String(info.Method),
+		}
+		spanAttrs := append([]attribute.KeyValue{
+			URLKey.String(info.URL),
+			RetryCountKey.Int(int(info.Retries)),
+			ReauthenticatedKey.Bool(info.Reauthenticated),
+		}, attrs...)
+		if info.Microversion != "" {
+			spanAttrs = append(spanAttrs, MicroversionKey.String(info.Microversion))
+		}
+
+		ctx, span := i.tracer.Start(req.Context(), serviceType+" "+info.Method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(spanAttrs...),
+		)
+		defer span.End()
+
+		if info.Reauthenticated {
+			span.AddEvent("reauthenticated")
+		}
+
+		req = req.WithContext(ctx)
+		i.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+		start := time.Now()
+		resp, err := next(req, info)
+		elapsed := time.Since(start).Seconds()
+
+		switch {
+		case err != nil:
+			attrs = append(attrs, ErrorTypeKey.String("transport"))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		case resp.StatusCode >= 400:
+			attrs = append(attrs,
+				StatusCodeKey.Int(resp.StatusCode),
+				ErrorTypeKey.String(strconv.Itoa(resp.StatusCode)),
+			)
+			span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		default:
+			attrs = append(attrs, StatusCodeKey.Int(resp.StatusCode))
+			span.SetAttributes(StatusCodeKey.Int(resp.StatusCode))
+		}
+
+		set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+		i.duration.Record(ctx, elapsed, set)
+		if err != nil || resp.StatusCode >= 400 {
+			i.errorCount.Add(ctx, 1, set)
+		}
+
+		return resp, err
+	}
+}
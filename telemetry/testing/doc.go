@@ -0,0 +1,2 @@
+// telemetry unit tests
+package testing
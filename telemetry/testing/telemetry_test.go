@@ -0,0 +1,142 @@
+package testing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	"github.com/gophercloud/gophercloud/v2/telemetry"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup(t *testing.T) (*gophercloud.ServiceClient, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	p := &gophercloud.ProviderClient{TokenID: "abc123"}
+	err := telemetry.Instrument(p,
+		telemetry.WithTracerProvider(tp),
+		telemetry.WithMeterProvider(mp),
+		telemetry.WithPropagator(propagation.TraceContext{}),
+	)
+	th.AssertNoErr(t, err)
+
+	return &gophercloud.ServiceClient{
+		ProviderClient: p,
+		Endpoint:       th.Endpoint(),
+		Type:           "compute",
+		Microversion:   "2.60",
+	}, recorder, reader
+}
+
+func attrValue(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestSpanAttributes(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Traceparent") == "" {
+			t.Errorf("Expected a traceparent header")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	sc, recorder, _ := setup(t)
+	_, err := sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, nil)
+	th.AssertNoErr(t, err)
+
+	spans := recorder.Ended()
+	th.AssertEquals(t, 1, len(spans))
+	span := spans[0]
+	th.CheckEquals(t, "compute GET", span.Name())
+	th.CheckEquals(t, codes.Unset, span.Status().Code)
+
+	attrs := span.Attributes()
+	v, _ := attrValue(attrs, telemetry.ServiceTypeKey)
+	th.CheckEquals(t, "compute", v.AsString())
+	v, _ = attrValue(attrs, telemetry.MicroversionKey)
+	th.CheckEquals(t, "2.60", v.AsString())
+	v, _ = attrValue(attrs, telemetry.MethodKey)
+	th.CheckEquals(t, "GET", v.AsString())
+	v, _ = attrValue(attrs, telemetry.StatusCodeKey)
+	th.CheckEquals(t, int64(200), v.AsInt64())
+	v, _ = attrValue(attrs, telemetry.RetryCountKey)
+	th.CheckEquals(t, int64(0), v.AsInt64())
+}
+
+func TestSpanReauthAndError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	sc, recorder, reader := setup(t)
+	sc.ReauthFunc = func(context.Context) error {
+		return nil
+	}
+
+	_, err := sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, nil)
+	th.AssertErr(t, err)
+
+	spans := recorder.Ended()
+	th.AssertEquals(t, 2, len(spans))
+	th.CheckEquals(t, codes.Error, spans[0].Status().Code)
+	th.CheckEquals(t, 0, len(spans[0].Events()))
+
+	th.CheckEquals(t, codes.Error, spans[1].Status().Code)
+	th.AssertEquals(t, 1, len(spans[1].Events()))
+	th.CheckEquals(t, "reauthenticated", spans[1].Events()[0].Name)
+	v, _ := attrValue(spans[1].Attributes(), telemetry.StatusCodeKey)
+	th.CheckEquals(t, int64(404), v.AsInt64())
+
+	var rm metricdata.ResourceMetrics
+	th.AssertNoErr(t, reader.Collect(context.TODO(), &rm))
+	th.AssertEquals(t, 1, len(rm.ScopeMetrics))
+
+	metrics := map[string]metricdata.Metrics{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+
+	duration := metrics["openstack.client.request.duration"].Data.(metricdata.Histogram[float64])
+	var count uint64
+	for _, dp := range duration.DataPoints {
+		count += dp.Count
+		v, _ := dp.Attributes.Value(telemetry.ServiceTypeKey)
+		th.CheckEquals(t, "compute", v.AsString())
+	}
+	th.CheckEquals(t, uint64(2), count)
+
+	errs := metrics["openstack.client.request.errors"].Data.(metricdata.Sum[int64])
+	var total int64
+	for _, dp := range errs.DataPoints {
+		total += dp.Value
+	}
+	th.CheckEquals(t, int64(2), total)
+}
@@ -207,3 +207,155 @@ func TestToTokenV3ScopeMap(t *testing.T) {
 		})
 	}
 }
+
+func TestToTokenV3HeadersMapAuthReceipt(t *testing.T) {
+	opts := gophercloud.AuthOptions{UserID: "me", Passcode: "123456"}
+	headers, err := opts.ToTokenV3HeadersMap(nil)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 0, len(headers))
+
+	opts.AuthReceipt = "receipt-id"
+	opts.AllowReauth = true
+	headers, err = opts.ToTokenV3HeadersMap(nil)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, map[string]string{"Openstack-Auth-Receipt": "receipt-id"}, headers)
+	th.AssertEquals(t, false, opts.CanReauth())
+}
+
+func TestTokenCacheKey(t *testing.T) {
+	opts := gophercloud.AuthOptions{
+		IdentityEndpoint: "http://keystone/v3",
+		Username:         "me",
+		Password:         "secret",
+		DomainName:       "default",
+		TenantName:       "project",
+	}
+	key := opts.TokenCacheKey()
+	th.AssertEquals(t, 64, len(key))
+
+	// The scope is part of the key.
+	other := opts
+	other.TenantName = "other"
+	th.AssertEquals(t, true, key != other.TokenCacheKey())
+
+	// And so is the authentication method.
+	other = opts
+	other.Password = ""
+	other.Passcode = "123456"
+	th.AssertEquals(t, true, key != other.TokenCacheKey())
+}
+
+func TestTokenCacheKeyCredentials(t *testing.T) {
+	for name, tc := range map[string]struct {
+		opts   gophercloud.AuthOptions
+		modify func(opts *gophercloud.AuthOptions)
+	}{
+		"password": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint: "http://keystone/v3",
+				Username:         "me",
+				Password:         "secret-a",
+				DomainName:       "default",
+			},
+			modify: func(opts *gophercloud.AuthOptions) { opts.Password = "secret-b" },
+		},
+		"passcode": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint: "http://keystone/v3",
+				UserID:           "me",
+				Password:         "secret",
+				Passcode:         "123456",
+			},
+			modify: func(opts *gophercloud.AuthOptions) { opts.Passcode = "654321" },
+		},
+		"token": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint: "http://keystone/v3",
+				TokenID:          "token-a",
+				TenantName:       "project",
+			},
+			modify: func(opts *gophercloud.AuthOptions) { opts.TokenID = "token-b" },
+		},
+		"application credential": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint:            "http://keystone/v3",
+				ApplicationCredentialID:     "app-cred",
+				ApplicationCredentialSecret: "secret-a",
+			},
+			modify: func(opts *gophercloud.AuthOptions) { opts.ApplicationCredentialSecret = "secret-b" },
+		},
+		"OIDC access token": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint: "http://keystone/v3",
+				TenantName:       "project",
+				OIDC: &gophercloud.OIDCAuthOptions{
+					IdentityProvider: "sso",
+					Protocol:         "openid",
+					AccessToken:      "access-a",
+				},
+			},
+			modify: func(opts *gophercloud.AuthOptions) {
+				oidc := *opts.OIDC
+				oidc.AccessToken = "access-b"
+				opts.OIDC = &oidc
+			},
+		},
+		"OIDC client secret": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint: "http://keystone/v3",
+				TenantName:       "project",
+				OIDC: &gophercloud.OIDCAuthOptions{
+					IdentityProvider: "sso",
+					Protocol:         "openid",
+					ClientID:         "client",
+					ClientSecret:     "secret-a",
+					GrantType:        gophercloud.OIDCGrantClientCredentials,
+				},
+			},
+			modify: func(opts *gophercloud.AuthOptions) {
+				oidc := *opts.OIDC
+				oidc.ClientSecret = "secret-b"
+				opts.OIDC = &oidc
+			},
+		},
+		"OIDC password": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint: "http://keystone/v3",
+				Username:         "me",
+				Password:         "secret-a",
+				TenantName:       "project",
+				OIDC: &gophercloud.OIDCAuthOptions{
+					IdentityProvider: "sso",
+					Protocol:         "openid",
+					ClientID:         "client",
+					GrantType:        gophercloud.OIDCGrantPassword,
+				},
+			},
+			modify: func(opts *gophercloud.AuthOptions) { opts.Password = "secret-b" },
+		},
+		"OIDC grant type": {
+			opts: gophercloud.AuthOptions{
+				IdentityEndpoint: "http://keystone/v3",
+				TenantName:       "project",
+				OIDC: &gophercloud.OIDCAuthOptions{
+					IdentityProvider: "sso",
+					Protocol:         "openid",
+					ClientID:         "client",
+					GrantType:        gophercloud.OIDCGrantClientCredentials,
+				},
+			},
+			modify: func(opts *gophercloud.AuthOptions) {
+				oidc := *opts.OIDC
+				oidc.GrantType = gophercloud.OIDCGrantAuthorizationCode
+				oidc.AuthorizationCode = "code"
+				opts.OIDC = &oidc
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			other := tc.opts
+			tc.modify(&other)
+			th.AssertEquals(t, true, tc.opts.TokenCacheKey() != other.TokenCacheKey())
+		})
+	}
+}
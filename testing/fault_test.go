@@ -0,0 +1,150 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestFault(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body     string
+		expected gophercloud.Fault
+	}{
+		"nova": {
+			body: `{"itemNotFound": {"message": "Instance 1234 could not be found.", "code": 404}}`,
+			expected: gophercloud.Fault{
+				Type:    "itemNotFound",
+				Code:    404,
+				Message: "Instance 1234 could not be found.",
+			},
+		},
+		"cinder with details": {
+			body: `{"badRequest": {"message": "Invalid input received", "code": 400, "details": "size must be positive"}}`,
+			expected: gophercloud.Fault{
+				Type:    "badRequest",
+				Code:    400,
+				Message: "Invalid input received",
+				Details: "size must be positive",
+			},
+		},
+		"string code": {
+			body: `{"itemNotFound": {"message": "Instance 1234 could not be found.", "code": "404"}}`,
+			expected: gophercloud.Fault{
+				Type:    "itemNotFound",
+				Code:    404,
+				Message: "Instance 1234 could not be found.",
+			},
+		},
+		"neutron": {
+			body: `{"NeutronError": {"type": "PortNotFound", "message": "Port 1234 could not be found.", "detail": ""}}`,
+			expected: gophercloud.Fault{
+				Type:    "PortNotFound",
+				Message: "Port 1234 could not be found.",
+			},
+		},
+		"keystone": {
+			body: `{"error": {"code": 401, "title": "Unauthorized", "message": "The request you have made requires authentication."}}`,
+			expected: gophercloud.Fault{
+				Type:    "Unauthorized",
+				Code:    401,
+				Message: "The request you have made requires authentication.",
+			},
+		},
+		"octavia": {
+			body: `{"faultcode": "Client", "faultstring": "Load Balancer 1234 not found.", "debuginfo": null}`,
+			expected: gophercloud.Fault{
+				Type:    "Client",
+				Message: "Load Balancer 1234 not found.",
+			},
+		},
+		"ironic": {
+			body: `{"error_message": "{\"faultcode\": \"Client\", \"faultstring\": \"Node 1234 could not be found.\", \"debuginfo\": null}"}`,
+			expected: gophercloud.Fault{
+				Type:    "Client",
+				Message: "Node 1234 could not be found.",
+			},
+		},
+		"heat": {
+			body: `{"explanation": "The resource could not be found.", "code": 404, "error": {"message": "The Stack (foo) could not be found.", "traceback": null, "type": "EntityNotFound"}, "title": "Not Found"}`,
+			expected: gophercloud.Fault{
+				Type:    "EntityNotFound",
+				Code:    404,
+				Message: "The Stack (foo) could not be found.",
+			},
+		},
+		"placement": {
+			body: `{"errors": [{"status": 404, "title": "Not Found", "detail": "No resource provider with uuid 1234 found", "code": "placement.undefined_code", "request_id": "req-1"}]}`,
+			expected: gophercloud.Fault{
+				Type:    "Not Found",
+				Code:    404,
+				Details: "No resource provider with uuid 1234 found",
+			},
+		},
+		"designate": {
+			body: `{"code": 404, "type": "zone_not_found", "message": "Could not find Zone", "request_id": "req-1"}`,
+			expected: gophercloud.Fault{
+				Type:    "zone_not_found",
+				Code:    404,
+				Message: "Could not find Zone",
+			},
+		},
+		"barbican": {
+			body: `{"code": 404, "title": "Not Found", "description": "Secret not found."}`,
+			expected: gophercloud.Fault{
+				Type:    "Not Found",
+				Code:    404,
+				Message: "Secret not found.",
+			},
+		},
+		"plain text": {
+			body: "404 Not Found\n\nThe resource could not be found.\n\n",
+			expected: gophercloud.Fault{
+				Message: "404 Not Found\n\nThe resource could not be found.",
+			},
+		},
+		"unknown JSON": {
+			body: `{"foo": "bar", "baz": 1}`,
+			expected: gophercloud.Fault{
+				Message: `{"foo": "bar", "baz": 1}`,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := gophercloud.ErrUnexpectedResponseCode{
+				Body:           []byte(tc.body),
+				ResponseHeader: http.Header{},
+			}
+			th.CheckDeepEquals(t, tc.expected, err.Fault())
+		})
+	}
+}
+
+func TestExtractFault(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/servers/1234", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Compute-Request-Id", "req-compute")
+		w.Header().Set("X-Openstack-Request-Id", "req-openstack")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"itemNotFound": {"message": "Instance 1234 could not be found.", "code": 404}}`)
+	})
+
+	p := &gophercloud.ProviderClient{}
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"servers/1234", &gophercloud.RequestOpts{})
+
+	fault, ok := gophercloud.ExtractFault(err)
+	th.AssertEquals(t, true, ok)
+	th.CheckEquals(t, "itemNotFound", fault.Type)
+	th.CheckEquals(t, 404, fault.Code)
+	th.CheckEquals(t, "req-openstack", fault.RequestID)
+
+	_, ok = gophercloud.ExtractFault(nil)
+	th.CheckEquals(t, false, ok)
+}
@@ -0,0 +1,144 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+const computeVersionDocument = `
+{
+	"version": {
+		"id": "v2.1",
+		"status": "CURRENT",
+		"version": "2.90",
+		"min_version": "2.1"
+	}
+}
+`
+
+func setupMicroversions(t *testing.T, versionRequests *int, sent *[]string) {
+	th.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		*versionRequests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, computeVersionDocument)
+	})
+	th.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		version := r.Header.Get("X-OpenStack-Nova-API-Version")
+		*sent = append(*sent, version)
+		if version != "" {
+			th.TestHeader(t, r, "OpenStack-API-Version", "compute "+version)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestMicroversionNegotiation(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	versionRequests := 0
+	var sent []string
+	setupMicroversions(t, &versionRequests, &sent)
+
+	p := &gophercloud.ProviderClient{NegotiateMicroversions: true}
+	sc := &gophercloud.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "compute"}
+
+	// No minimum: the client microversion is used as is.
+	_, err := sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, nil)
+	th.AssertNoErr(t, err)
+
+	// The minimum is higher than the client microversion, so it is used.
+	_, err = sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, &gophercloud.RequestOpts{MinMicroversion: "2.78"})
+	th.AssertNoErr(t, err)
+
+	// A copy of the client shares the cache of the provider.
+	copied := *sc
+	copied.Microversion = "2.80"
+	_, err = copied.Get(context.TODO(), copied.ServiceURL("servers"), nil, &gophercloud.RequestOpts{MinMicroversion: "2.78"})
+	th.AssertNoErr(t, err)
+
+	// The minimum is higher than the service supports.
+	_, err = sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, &gophercloud.RequestOpts{MinMicroversion: "2.97"})
+	var mvErr gophercloud.ErrMicroversionNotSupported
+	if !errors.As(err, &mvErr) {
+		t.Fatalf("Expected ErrMicroversionNotSupported, got %v", err)
+	}
+	th.CheckDeepEquals(t, &gophercloud.MicroversionRange{Min: "2.1", Max: "2.90"}, mvErr.Supported)
+	th.CheckEquals(t, "The request requires compute microversion 2.97 or later, but the service only supports microversions 2.1 to 2.90", err.Error())
+
+	th.CheckDeepEquals(t, []string{"", "2.78", "2.80"}, sent)
+	th.CheckEquals(t, 1, versionRequests)
+	th.CheckEquals(t, "", sc.Microversion)
+}
+
+func TestMicroversionWithoutNegotiation(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	versionRequests := 0
+	var sent []string
+	setupMicroversions(t, &versionRequests, &sent)
+
+	p := &gophercloud.ProviderClient{}
+	sc := &gophercloud.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "compute", Microversion: "2.60"}
+
+	_, err := sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, &gophercloud.RequestOpts{MinMicroversion: "2.53"})
+	th.AssertNoErr(t, err)
+
+	_, err = sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, &gophercloud.RequestOpts{MinMicroversion: "2.78"})
+	var mvErr gophercloud.ErrMicroversionNotSupported
+	if !errors.As(err, &mvErr) {
+		t.Fatalf("Expected ErrMicroversionNotSupported, got %v", err)
+	}
+	th.CheckEquals(t, "The request requires compute microversion 2.78 or later, but the service client is set to 2.60", err.Error())
+
+	th.CheckDeepEquals(t, []string{"2.60"}, sent)
+	th.CheckEquals(t, 0, versionRequests)
+}
+
+func TestMicroversionLatest(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	versionRequests := 0
+	var sent []string
+	setupMicroversions(t, &versionRequests, &sent)
+
+	p := &gophercloud.ProviderClient{}
+	sc := &gophercloud.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "compute", Microversion: "latest"}
+
+	_, err := sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, &gophercloud.RequestOpts{MinMicroversion: "2.78"})
+	th.AssertNoErr(t, err)
+
+	th.CheckDeepEquals(t, []string{"latest"}, sent)
+	th.CheckEquals(t, 0, versionRequests)
+}
+
+func TestSupportedMicroversions(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	versionRequests := 0
+	var sent []string
+	setupMicroversions(t, &versionRequests, &sent)
+
+	sc := &gophercloud.ServiceClient{ProviderClient: &gophercloud.ProviderClient{}, Endpoint: th.Endpoint(), Type: "compute"}
+	r, err := sc.SupportedMicroversions(context.TODO())
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, gophercloud.MicroversionRange{Min: "2.1", Max: "2.90"}, r)
+
+	for version, expected := range map[string]bool{"2.0": false, "2.1": true, "2.53": true, "2.90": true, "2.91": false, "3.1": false} {
+		actual, err := r.Contains(version)
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, expected, actual)
+	}
+
+	_, err = r.Contains("two")
+	th.AssertErr(t, err)
+}
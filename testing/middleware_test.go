@@ -0,0 +1,180 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Signature", "outer,inner")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var calls []string
+	tag := func(name string) gophercloud.Middleware {
+		return func(next gophercloud.RequestFunc) gophercloud.RequestFunc {
+			return func(req *http.Request, info *gophercloud.RequestInfo) (*http.Response, error) {
+				calls = append(calls, "before "+name)
+				if sig := req.Header.Get("X-Signature"); sig != "" {
+					req.Header.Set("X-Signature", sig+","+name)
+				} else {
+					req.Header.Set("X-Signature", name)
+				}
+				resp, err := next(req, info)
+				calls = append(calls, "after "+name)
+				return resp, err
+			}
+		}
+	}
+
+	p := &gophercloud.ProviderClient{}
+	p.Use(tag("outer"), tag("inner"))
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"before outer", "before inner", "after inner", "after outer"}, calls)
+}
+
+func TestMiddlewareRequestInfo(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", "abc123")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var infos []gophercloud.RequestInfo
+	var tokens []string
+	p := &gophercloud.ProviderClient{TokenID: "abc123"}
+	p.Use(gophercloud.RequestHook(func(req *http.Request, info *gophercloud.RequestInfo) error {
+		infos = append(infos, *info)
+		tokens = append(tokens, req.Header.Get("X-Auth-Token"))
+		return nil
+	}))
+
+	sc := &gophercloud.ServiceClient{
+		ProviderClient: p,
+		Endpoint:       th.Endpoint(),
+		Type:           "compute",
+		Microversion:   "2.79",
+	}
+
+	_, err := sc.Get(context.TODO(), sc.ServiceURL("servers"), nil, nil)
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 1, len(infos))
+	th.CheckEquals(t, "GET", infos[0].Method)
+	th.CheckEquals(t, th.Endpoint()+"servers", infos[0].URL)
+	th.CheckEquals(t, "compute", infos[0].ServiceType)
+	th.CheckEquals(t, "2.79", infos[0].Microversion)
+	th.CheckEquals(t, uint(0), infos[0].Retries)
+	th.CheckEquals(t, false, infos[0].Reauthenticated)
+	th.CheckEquals(t, "abc123", tokens[0])
+}
+
+func TestMiddlewareSeesReauthAndRetries(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.WriteHeader(http.StatusUnauthorized)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	var infos []gophercloud.RequestInfo
+	var statuses []int
+	p := &gophercloud.ProviderClient{TokenID: "abc123"}
+	p.ReauthFunc = func(context.Context) error {
+		p.SetToken("def456")
+		return nil
+	}
+	p.RetryBackoffFunc = func(context.Context, *gophercloud.ErrUnexpectedResponseCode, error, uint) error {
+		return nil
+	}
+	p.Use(gophercloud.ResponseHook(func(resp *http.Response, err error, info *gophercloud.RequestInfo) {
+		th.AssertNoErr(t, err)
+		infos = append(infos, *info)
+		statuses = append(statuses, resp.StatusCode)
+	}))
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 3, len(infos))
+	th.CheckDeepEquals(t, []int{401, 429, 200}, statuses)
+	th.CheckEquals(t, false, infos[0].Reauthenticated)
+	th.CheckEquals(t, true, infos[1].Reauthenticated)
+	th.CheckEquals(t, uint(0), infos[1].Retries)
+	th.CheckEquals(t, uint(1), infos[2].Retries)
+}
+
+func TestMiddlewareRequestHookAbort(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request should not have been sent")
+	})
+
+	hookErr := errors.New("unable to sign request")
+	p := &gophercloud.ProviderClient{}
+	p.Use(gophercloud.RequestHook(func(*http.Request, *gophercloud.RequestInfo) error {
+		return hookErr
+	}))
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	if !errors.Is(err, hookErr) {
+		t.Fatalf("Expected %v, got %v", hookErr, err)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Request should not have been sent")
+	})
+
+	p := &gophercloud.ProviderClient{}
+	p.Use(func(gophercloud.RequestFunc) gophercloud.RequestFunc {
+		return func(req *http.Request, info *gophercloud.RequestInfo) (*http.Response, error) {
+			return nil, fmt.Errorf("blocked %s %s", info.Method, info.URL)
+		}
+	})
+
+	_, err := p.Request(context.TODO(), "DELETE", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, "blocked DELETE "+th.Endpoint()+"route", err.Error())
+}
+
+func TestMiddlewareNilResponse(t *testing.T) {
+	p := &gophercloud.ProviderClient{}
+	p.Use(func(next gophercloud.RequestFunc) gophercloud.RequestFunc {
+		return func(req *http.Request, info *gophercloud.RequestInfo) (*http.Response, error) {
+			return nil, nil
+		}
+	})
+
+	_, err := p.Request(context.TODO(), "GET", "http://localhost/route", &gophercloud.RequestOpts{})
+	th.AssertErr(t, err)
+}
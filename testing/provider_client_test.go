@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -29,6 +30,25 @@ func TestAuthenticatedHeaders(t *testing.T) {
 	th.CheckDeepEquals(t, expected, actual)
 }
 
+func TestRequestOmitAuthenticatedHeaders(t *testing.T) {
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("X-Auth-Token"))
+	}))
+	defer ts.Close()
+
+	p := &gophercloud.ProviderClient{TokenID: "1234"}
+
+	_, err := p.Request(context.TODO(), "GET", ts.URL, &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+	_, err = p.Request(context.TODO(), "GET", ts.URL, &gophercloud.RequestOpts{
+		OmitHeaders: []string{"x-auth-token"},
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertDeepEquals(t, []string{"1234", ""}, tokens)
+}
+
 func TestUserAgent(t *testing.T) {
 	p := &gophercloud.ProviderClient{}
 
@@ -710,3 +730,204 @@ func TestRequestWrongOkCode(t *testing.T) {
 		t.Fatalf("expected error type gophercloud.ErrUnexpectedResponseCode but got %T", err)
 	}
 }
+
+type expiringAuthResult struct {
+	tokenID   string
+	expiresAt time.Time
+}
+
+func (r expiringAuthResult) ExtractTokenID() (string, error) {
+	return r.tokenID, nil
+}
+
+func (r expiringAuthResult) ExtractExpiresAt() (time.Time, error) {
+	return r.expiresAt, nil
+}
+
+func TestRequestRenewsExpiringToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	p := new(gophercloud.ProviderClient)
+	p.UseTokenLock()
+	p.TokenRenewalMargin = 5 * time.Minute
+	err := p.SetTokenAndAuthResult(expiringAuthResult{"old", time.Now().Add(time.Minute)})
+	th.AssertNoErr(t, err)
+
+	var reauths atomic.Int32
+	p.ReauthFunc = func(_ context.Context) error {
+		reauths.Add(1)
+		return p.SetTokenAndAuthResult(expiringAuthResult{"new", time.Now().Add(time.Hour)})
+	}
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for range 2 {
+		_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+		th.AssertNoErr(t, err)
+	}
+	th.AssertEquals(t, int32(1), reauths.Load())
+
+	expiresAt, ok := p.TokenExpiresAt()
+	th.AssertEquals(t, true, ok)
+	th.AssertEquals(t, true, time.Until(expiresAt) > 5*time.Minute)
+}
+
+func TestRequestThrottlesTokenRenewal(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for name, tc := range map[string]struct {
+		reauth func(p *gophercloud.ProviderClient) error
+		// reauths is the number of reauthentications once the retry delay
+		// has elapsed.
+		reauths int32
+	}{
+		"short-lived tokens": {
+			reauth: func(p *gophercloud.ProviderClient) error {
+				return p.SetTokenAndAuthResult(expiringAuthResult{"new", time.Now().Add(time.Minute)})
+			},
+			reauths: 1,
+		},
+		"failing renewals": {
+			reauth: func(p *gophercloud.ProviderClient) error {
+				return errors.New("keystone is down")
+			},
+			reauths: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := new(gophercloud.ProviderClient)
+			p.UseTokenLock()
+			p.TokenRenewalMargin = 5 * time.Minute
+			p.TokenRenewalRetryDelay = 200 * time.Millisecond
+			err := p.SetTokenAndAuthResult(expiringAuthResult{"old", time.Now().Add(time.Minute)})
+			th.AssertNoErr(t, err)
+
+			var reauths atomic.Int32
+			p.ReauthFunc = func(_ context.Context) error {
+				reauths.Add(1)
+				return tc.reauth(p)
+			}
+
+			for range 5 {
+				_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+				th.AssertNoErr(t, err)
+			}
+			th.AssertEquals(t, int32(1), reauths.Load())
+
+			time.Sleep(300 * time.Millisecond)
+			for range 5 {
+				_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+				th.AssertNoErr(t, err)
+			}
+			th.AssertEquals(t, tc.reauths, reauths.Load())
+		})
+	}
+}
+
+func TestRequestKeepsValidToken(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	p := new(gophercloud.ProviderClient)
+	p.TokenRenewalMargin = 5 * time.Minute
+	err := p.SetTokenAndAuthResult(expiringAuthResult{"old", time.Now().Add(time.Hour)})
+	th.AssertNoErr(t, err)
+	p.ReauthFunc = func(_ context.Context) error {
+		t.Error("unexpected reauthentication")
+		return nil
+	}
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", "old")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	_, err = p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+}
+
+// countingAuthResult counts how many times the expiry of the token is parsed.
+type countingAuthResult struct {
+	expiringAuthResult
+	parses *atomic.Int32
+}
+
+func (r countingAuthResult) ExtractExpiresAt() (time.Time, error) {
+	r.parses.Add(1)
+	return r.expiringAuthResult.ExtractExpiresAt()
+}
+
+func TestRequestParsesTokenExpiryOnce(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var parses atomic.Int32
+	p := new(gophercloud.ProviderClient)
+	p.TokenRenewalMargin = 5 * time.Minute
+	err := p.SetTokenAndAuthResult(countingAuthResult{expiringAuthResult{"old", time.Now().Add(time.Hour)}, &parses})
+	th.AssertNoErr(t, err)
+	p.ReauthFunc = func(_ context.Context) error {
+		t.Error("unexpected reauthentication")
+		return nil
+	}
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for range 3 {
+		_, err = p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+		th.AssertNoErr(t, err)
+	}
+	th.AssertEquals(t, int32(1), parses.Load())
+
+	p.SetToken("manual")
+	_, ok := p.TokenExpiresAt()
+	th.AssertEquals(t, false, ok)
+}
+
+func TestRunTokenRenewal(t *testing.T) {
+	p := new(gophercloud.ProviderClient)
+	p.UseTokenLock()
+	err := p.SetTokenAndAuthResult(expiringAuthResult{"old", time.Now().Add(time.Minute)})
+	th.AssertNoErr(t, err)
+
+	renewed := make(chan struct{})
+	p.ReauthFunc = func(_ context.Context) error {
+		defer close(renewed)
+		return p.SetTokenAndAuthResult(expiringAuthResult{"new", time.Now().Add(time.Hour)})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- p.RunTokenRenewal(ctx, 5*time.Minute)
+	}()
+
+	select {
+	case <-renewed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the token was not renewed")
+	}
+	th.AssertEquals(t, "new", p.Token())
+
+	cancel()
+	th.AssertEquals(t, context.Canceled, <-done)
+}
+
+func TestRunTokenRenewalWithoutReauthFunc(t *testing.T) {
+	p := new(gophercloud.ProviderClient)
+	th.AssertErr(t, p.RunTokenRenewal(context.TODO(), time.Minute))
+}
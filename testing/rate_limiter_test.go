@@ -0,0 +1,116 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := gophercloud.NewRateLimiter(10, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		th.AssertNoErr(t, l.Wait(context.TODO()))
+	}
+	if d := time.Since(start); d > 50*time.Millisecond {
+		t.Errorf("Expected the burst to be immediate, took %s", d)
+	}
+
+	th.AssertNoErr(t, l.Wait(context.TODO()))
+	if d := time.Since(start); d < 80*time.Millisecond {
+		t.Errorf("Expected the request after the burst to wait, took %s", d)
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	var l *gophercloud.RateLimiter
+	th.AssertNoErr(t, l.Wait(context.TODO()))
+
+	l = gophercloud.NewRateLimiter(0, 0)
+	for i := 0; i < 100; i++ {
+		th.AssertNoErr(t, l.Wait(context.TODO()))
+	}
+}
+
+func TestRateLimiterContext(t *testing.T) {
+	l := gophercloud.NewRateLimiter(0.1, 1)
+	th.AssertNoErr(t, l.Wait(context.TODO()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := l.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRateLimiterConcurrent(t *testing.T) {
+	l := gophercloud.NewRateLimiter(100, 1)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			th.AssertNoErr(t, l.Wait(context.TODO()))
+		}()
+	}
+	wg.Wait()
+
+	if d := time.Since(start); d < 80*time.Millisecond {
+		t.Errorf("Expected 10 requests at 100 QPS to take at least 90ms, took %s", d)
+	}
+}
+
+func TestServiceClientRateLimiter(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	count := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusOK)
+	})
+
+	p := &gophercloud.ProviderClient{
+		RateLimiters: map[string]*gophercloud.RateLimiter{
+			"compute": gophercloud.NewRateLimiter(0.001, 1),
+		},
+	}
+	compute := &gophercloud.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "compute"}
+	network := &gophercloud.ServiceClient{ProviderClient: p, Endpoint: th.Endpoint(), Type: "network"}
+
+	// The first compute request uses the burst, the second one has to wait
+	// much longer than the context allows.
+	_, err := compute.Get(context.TODO(), compute.ServiceURL("route"), nil, nil)
+	th.AssertNoErr(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = compute.Get(ctx, compute.ServiceURL("route"), nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// Other services are not limited.
+	for i := 0; i < 3; i++ {
+		_, err = network.Get(context.TODO(), network.ServiceURL("route"), nil, nil)
+		th.AssertNoErr(t, err)
+	}
+
+	// A limiter set on the service client takes precedence.
+	compute.RateLimiter = gophercloud.NewRateLimiter(1000, 5)
+	_, err = compute.Get(context.TODO(), compute.ServiceURL("route"), nil, nil)
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, 5, count)
+}
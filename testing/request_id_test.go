@@ -0,0 +1,121 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/v2"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func TestGlobalRequestID(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	id := gophercloud.NewGlobalRequestID()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-OpenStack-Request-ID", id)
+		w.Header().Set("X-Openstack-Request-Id", "req-local")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ctx, err := gophercloud.WithGlobalRequestID(context.TODO(), id)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, id, gophercloud.GlobalRequestIDFromContext(ctx))
+
+	p := &gophercloud.ProviderClient{}
+	resp, err := p.Request(ctx, "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+
+	var r gophercloud.Result
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	th.CheckEquals(t, "req-local", r.RequestID())
+}
+
+func TestGlobalRequestIDInvalid(t *testing.T) {
+	ctx := context.TODO()
+	_, err := gophercloud.WithGlobalRequestID(ctx, "my-request")
+	th.AssertErr(t, err)
+	th.CheckEquals(t, "", gophercloud.GlobalRequestIDFromContext(ctx))
+}
+
+func TestRequestIDFromError(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header["X-Openstack-Request-Id"]; ok {
+			t.Errorf("No global request ID should be sent")
+		}
+		w.Header().Set("X-Compute-Request-Id", "req-compute")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"conflictingRequest": {"message": "Cannot delete", "code": 409}}`)
+	})
+
+	p := &gophercloud.ProviderClient{}
+	resp, err := p.Request(context.TODO(), "DELETE", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.CheckEquals(t, "req-compute", gophercloud.RequestIDFromError(err))
+
+	var r gophercloud.Result
+	_, r.Header, r.Err = gophercloud.ParseResponse(resp, err)
+	th.CheckEquals(t, "req-compute", r.RequestID())
+
+	r = gophercloud.Result{Err: err}
+	th.CheckEquals(t, "req-compute", r.RequestID())
+
+	th.CheckEquals(t, "", gophercloud.RequestIDFromError(nil))
+}
+
+func TestRequestIDFromErrorAfterReauth(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") == "old" {
+			w.Header().Set("X-Openstack-Request-Id", "req-unauthorized")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-Compute-Request-Id", "req-compute")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"itemNotFound": {"message": "Instance 1234 could not be found.", "code": 404}}`)
+	})
+
+	p := &gophercloud.ProviderClient{}
+	p.SetToken("old")
+	p.ReauthFunc = func(_ context.Context) error {
+		p.SetToken("new")
+		return nil
+	}
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, "req-compute", gophercloud.RequestIDFromError(err))
+}
+
+func TestRequestIDFromErrorReauthFailed(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Openstack-Request-Id", "req-unauthorized")
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	reauthErr := fmt.Errorf("invalid credentials")
+	p := &gophercloud.ProviderClient{}
+	p.SetToken("old")
+	p.ReauthFunc = func(_ context.Context) error {
+		return reauthErr
+	}
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, "req-unauthorized", gophercloud.RequestIDFromError(err))
+
+	// The reauthentication error does not match the 401 response.
+	th.CheckEquals(t, false, gophercloud.ResponseCodeIs(err, http.StatusUnauthorized))
+}
@@ -0,0 +1,265 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/v2"
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+)
+
+func newRetryPolicyClient(policy gophercloud.RetryPolicy) *gophercloud.ProviderClient {
+	p := &gophercloud.ProviderClient{}
+	p.RetryFunc = policy.Retry
+	p.RetryBackoffFunc = policy.Backoff
+	return p
+}
+
+func TestRetryPolicyRetriesServerErrors(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var bodies []string
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		switch len(bodies) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Millisecond})
+
+	// The body starts at an offset, which must be kept when it is rewound.
+	body := strings.NewReader("skip:payload")
+	_, err := body.Seek(5, io.SeekStart)
+	th.AssertNoErr(t, err)
+
+	_, err = p.Request(context.TODO(), "PUT", th.Endpoint()+"route", &gophercloud.RequestOpts{
+		RawBody: body,
+		OkCodes: []int{200},
+	})
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []string{"payload", "payload", "payload"}, bodies)
+}
+
+func TestRetryPolicyMaxRetries(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	count := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusGatewayTimeout)
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	if !gophercloud.ResponseCodeIs(err, http.StatusGatewayTimeout) {
+		t.Fatalf("Expected a 504 error, got %v", err)
+	}
+	th.CheckEquals(t, 3, count)
+}
+
+func TestRetryPolicyDoesNotRetryOtherErrors(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	count := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Millisecond})
+
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, 1, count)
+}
+
+func TestRetryPolicyNonIdempotent(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	count := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Millisecond})
+	_, err := p.Request(context.TODO(), "POST", th.Endpoint()+"route", &gophercloud.RequestOpts{JSONBody: map[string]string{}})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, 1, count)
+
+	count = 0
+	p = newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Millisecond, RetryNonIdempotent: true})
+	_, err = p.Request(context.TODO(), "POST", th.Endpoint()+"route", &gophercloud.RequestOpts{JSONBody: map[string]string{}})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 2, count)
+}
+
+func TestRetryPolicyNonSeekableBody(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	count := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Millisecond})
+	_, err := p.Request(context.TODO(), "PUT", th.Endpoint()+"route", &gophercloud.RequestOpts{
+		RawBody: io.MultiReader(bytes.NewReader([]byte("data"))),
+	})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, 1, count)
+}
+
+func TestRetryPolicyBackoffNonSeekableBody(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	count := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		_, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Millisecond})
+	_, err := p.Request(context.TODO(), "PUT", th.Endpoint()+"route", &gophercloud.RequestOpts{
+		RawBody: io.MultiReader(bytes.NewReader([]byte("data"))),
+	})
+	th.AssertEquals(t, true, gophercloud.ResponseCodeIs(err, http.StatusTooManyRequests))
+	th.CheckEquals(t, 1, count)
+}
+
+func TestRetryPolicyBackoffExhausted(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	count := 0
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("X-Openstack-Request-Id", "req-429")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond})
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertEquals(t, true, gophercloud.ResponseCodeIs(err, http.StatusTooManyRequests))
+	th.CheckEquals(t, "req-429", gophercloud.RequestIDFromError(err))
+	th.CheckEquals(t, 3, count)
+}
+
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var times []time.Time
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		times = append(times, time.Now())
+		if len(times) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Millisecond})
+	_, err := p.Request(context.TODO(), "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(times))
+	if d := times[1].Sub(times[0]); d < 900*time.Millisecond {
+		t.Errorf("Expected the retry to honor Retry-After, waited %s", d)
+	}
+}
+
+func TestRetryPolicyConnectionError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	th.AssertNoErr(t, err)
+	url := fmt.Sprintf("http://%s/route", listener.Addr())
+	th.AssertNoErr(t, listener.Close())
+
+	attempts := uint(0)
+	policy := gophercloud.RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+	p := &gophercloud.ProviderClient{}
+	p.RetryFunc = func(ctx context.Context, method, url string, options *gophercloud.RequestOpts, err error, failCount uint) error {
+		attempts = failCount
+		return policy.Retry(ctx, method, url, options, err, failCount)
+	}
+
+	_, err = p.Request(context.TODO(), "GET", url, &gophercloud.RequestOpts{})
+	th.AssertErr(t, err)
+	th.CheckEquals(t, uint(3), attempts)
+}
+
+func TestRetryPolicyContext(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/route", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	p := newRetryPolicyClient(gophercloud.RetryPolicy{BaseDelay: time.Hour})
+	start := time.Now()
+	_, err := p.Request(ctx, "GET", th.Endpoint()+"route", &gophercloud.RequestOpts{})
+	if !gophercloud.ResponseCodeIs(err, http.StatusServiceUnavailable) {
+		t.Fatalf("Expected a 503 error, got %v", err)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Errorf("Expected the backoff to be interrupted by the context")
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := gophercloud.RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for retry, expected := range map[uint]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		60: time.Second,
+	} {
+		delay := policy.Delay(retry)
+		if delay < expected/2 || delay > expected {
+			t.Errorf("Retry %d: expected a delay between %s and %s, got %s", retry, expected/2, expected, delay)
+		}
+	}
+}
+
+func TestRetryPolicyIgnoresCancellation(t *testing.T) {
+	policy := gophercloud.RetryPolicy{BaseDelay: time.Millisecond}
+	err := policy.Retry(context.TODO(), "GET", "", nil, context.Canceled, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
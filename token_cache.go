@@ -0,0 +1,117 @@
+package gophercloud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// CachedToken is a token stored in a TokenCache.
+type CachedToken struct {
+	// ID is the token.
+	ID string `json:"id"`
+
+	// ExpiresAt is the time at which the token expires.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Body is the body of the authentication response, which holds the
+	// service catalog.
+	Body json.RawMessage `json:"body"`
+}
+
+// TokenCache stores tokens, so that they can be reused by later
+// authentications with the same AuthOptions. The keys are returned by
+// AuthOptions.TokenCacheKey. Implementations must be safe for concurrent use.
+//
+// The cached tokens grant access to the cloud: implementations must store them
+// as securely as the credentials they were obtained with.
+type TokenCache interface {
+	// Load returns the token stored under key, or nil if there is none.
+	Load(ctx context.Context, key string) (*CachedToken, error)
+
+	// Store stores token under key, replacing any previous one.
+	Store(ctx context.Context, key string, token CachedToken) error
+
+	// Delete removes the token stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// TokenCacheKey returns the key under which the tokens obtained with the
+// options are cached. It is a hash of the identity endpoint, of the
+// authentication method, of the identity of the user and of the scope. The
+// credentials, such as the password, the OIDC client secret or a token, are
+// hashed into it as well, so that a cached token is only reused by the callers
+// who could have obtained it from Keystone. Since a TOTP passcode changes
+// with each login, logins with a Passcode do not use the TokenCache.
+func (opts AuthOptions) TokenCacheKey() string {
+	k := struct {
+		IdentityEndpoint          string     `json:"auth_url"`
+		Method                    string     `json:"method"`
+		Credential                string     `json:"credential,omitempty"`
+		UserID                    string     `json:"user_id,omitempty"`
+		Username                  string     `json:"username,omitempty"`
+		DomainID                  string     `json:"domain_id,omitempty"`
+		DomainName                string     `json:"domain_name,omitempty"`
+		TenantID                  string     `json:"project_id,omitempty"`
+		TenantName                string     `json:"project_name,omitempty"`
+		Scope                     *AuthScope `json:"scope,omitempty"`
+		ApplicationCredentialID   string     `json:"application_credential_id,omitempty"`
+		ApplicationCredentialName string     `json:"application_credential_name,omitempty"`
+		IdentityProvider          string     `json:"identity_provider,omitempty"`
+		Protocol                  string     `json:"protocol,omitempty"`
+		ClientID                  string     `json:"client_id,omitempty"`
+		GrantType                 string     `json:"grant_type,omitempty"`
+	}{
+		IdentityEndpoint:          opts.IdentityEndpoint,
+		UserID:                    opts.UserID,
+		Username:                  opts.Username,
+		DomainID:                  opts.DomainID,
+		DomainName:                opts.DomainName,
+		TenantID:                  opts.TenantID,
+		TenantName:                opts.TenantName,
+		Scope:                     opts.Scope,
+		ApplicationCredentialID:   opts.ApplicationCredentialID,
+		ApplicationCredentialName: opts.ApplicationCredentialName,
+	}
+
+	var credential string
+	switch {
+	case opts.OIDC != nil:
+		k.Method = "oidc"
+		k.IdentityProvider = opts.OIDC.IdentityProvider
+		k.Protocol = opts.OIDC.Protocol
+		k.ClientID = opts.OIDC.ClientID
+		if opts.OIDC.AccessToken != "" {
+			credential = opts.OIDC.AccessToken
+		} else {
+			k.GrantType = string(opts.OIDC.GrantType)
+			credential = strings.Join([]string{opts.OIDC.ClientSecret, opts.Password, opts.OIDC.AuthorizationCode}, "\x00")
+		}
+	case opts.Password != "" && opts.Passcode != "":
+		k.Method = "password,totp"
+		credential = opts.Password + "\x00" + opts.Passcode
+	case opts.Password != "":
+		k.Method = "password"
+		credential = opts.Password
+	case opts.Passcode != "":
+		k.Method = "totp"
+		credential = opts.Passcode
+	case opts.TokenID != "":
+		k.Method = "token"
+		credential = opts.TokenID
+	case opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "":
+		k.Method = "application_credential"
+		credential = opts.ApplicationCredentialSecret
+	}
+	if credential != "" {
+		sum := sha256.Sum256([]byte(credential))
+		k.Credential = hex.EncodeToString(sum[:])
+	}
+
+	b, _ := json.Marshal(k)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,151 @@
+package gophercloud
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultTokenRenewalRetryDelay is the TokenRenewalRetryDelay of a
+// ProviderClient when it is not set.
+const DefaultTokenRenewalRetryDelay = 30 * time.Second
+
+// tokenRenewalRetryDelay is the shortest interval between two renewals by
+// RunTokenRenewal or by the requests of a ProviderClient with a
+// TokenRenewalMargin. It is the delay before a failed renewal is retried, and
+// prevents renewing continuously when the lifetime of the tokens is shorter
+// than the renewal margin.
+func (client *ProviderClient) tokenRenewalRetryDelay() time.Duration {
+	if client.TokenRenewalRetryDelay <= 0 {
+		return DefaultTokenRenewalRetryDelay
+	}
+	return client.TokenRenewalRetryDelay
+}
+
+// TokenExpiresAt returns the time at which the current token expires. The
+// second return value is false when it is unknown, which is the case when the
+// AuthResult is not an ExpiringAuthResult.
+func (client *ProviderClient) TokenExpiresAt() (time.Time, bool) {
+	if client.mut != nil {
+		client.mut.RLock()
+		defer client.mut.RUnlock()
+	}
+	return client.tokenExpiresAt, !client.tokenExpiresAt.IsZero()
+}
+
+// authResultExpiresAt returns the expiry of the token of an AuthResult, or
+// the zero time when it is unknown.
+func authResultExpiresAt(r AuthResult) time.Time {
+	er, ok := r.(ExpiringAuthResult)
+	if !ok {
+		return time.Time{}
+	}
+	expiresAt, err := er.ExtractExpiresAt()
+	if err != nil {
+		return time.Time{}
+	}
+	return expiresAt
+}
+
+// renewExpiringToken reauthenticates if the token expires within the
+// TokenRenewalMargin. Renewals are at least TokenRenewalRetryDelay apart, and
+// stop once a renewal returns a token which already expires within the margin,
+// until a token with a longer lifetime is seen. Requests made meanwhile use
+// the current token. Errors are ignored: the token may still be valid, the
+// renewal is retried after the delay, and an expired token is renewed again
+// when the request fails with a 401.
+func (client *ProviderClient) renewExpiringToken(ctx context.Context) {
+	if client.TokenRenewalMargin <= 0 || client.ReauthFunc == nil || client.IsThrowaway() {
+		return
+	}
+	if !client.startTokenRenewal() {
+		return
+	}
+	if err := client.Reauthenticate(ctx, client.Token()); err == nil {
+		client.finishTokenRenewal()
+	}
+}
+
+// startTokenRenewal reports whether the token should be renewed, and records
+// the renewal attempt if so.
+func (client *ProviderClient) startTokenRenewal() bool {
+	if client.mut != nil {
+		client.mut.Lock()
+		defer client.mut.Unlock()
+	}
+	if client.tokenExpiresAt.IsZero() {
+		return false
+	}
+	if time.Until(client.tokenExpiresAt) > client.TokenRenewalMargin {
+		client.shortLivedTokens = false
+		return false
+	}
+	if client.shortLivedTokens || time.Since(client.tokenRenewedAt) < client.tokenRenewalRetryDelay() {
+		return false
+	}
+	client.tokenRenewedAt = time.Now()
+	return true
+}
+
+// finishTokenRenewal records whether the token returned by a renewal already
+// expires within the TokenRenewalMargin, in which case renewing it is useless.
+func (client *ProviderClient) finishTokenRenewal() {
+	if client.mut != nil {
+		client.mut.Lock()
+		defer client.mut.Unlock()
+	}
+	if !client.tokenExpiresAt.IsZero() && time.Until(client.tokenExpiresAt) <= client.TokenRenewalMargin {
+		client.shortLivedTokens = true
+	}
+}
+
+// RunTokenRenewal renews the token in the background, margin before it
+// expires, until ctx is done. It is meant to be run in its own goroutine, so
+// that requests never wait for a reauthentication:
+//
+//	provider.UseTokenLock()
+//	go provider.RunTokenRenewal(ctx, 5*time.Minute)
+//
+// Failed renewals are retried after TokenRenewalRetryDelay. Meanwhile,
+// requests still reauthenticate when they fail with a 401 response.
+// RunTokenRenewal returns the error of ctx when it is done, and an error right
+// away when the ProviderClient has no ReauthFunc. UseTokenLock must have been called, since
+// the token is updated concurrently with the requests.
+func (client *ProviderClient) RunTokenRenewal(ctx context.Context, margin time.Duration) error {
+	if client.ReauthFunc == nil {
+		return errors.New("token renewal requires a ReauthFunc")
+	}
+
+	var minDelay time.Duration
+	for {
+		// When the expiry is unknown, e.g. because the token was set with
+		// SetToken, check again later.
+		delay := client.tokenRenewalRetryDelay()
+		expiresAt, ok := client.TokenExpiresAt()
+		if ok {
+			delay = max(time.Until(expiresAt)-margin, minDelay)
+		}
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
+
+		// The token may have been renewed in the meantime.
+		expiresAt, ok = client.TokenExpiresAt()
+		if !ok || time.Until(expiresAt) > margin {
+			continue
+		}
+
+		_ = client.Reauthenticate(ctx, client.Token())
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		minDelay = client.tokenRenewalRetryDelay()
+	}
+}